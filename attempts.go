@@ -0,0 +1,104 @@
+package convoy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeliveryAttempt is one try at sending an event delivery to its endpoint.
+type DeliveryAttempt struct {
+	UID        string `json:"uid"`
+	DeliveryID string `json:"msg_id"`
+	URL        string `json:"url"`
+	Method     string `json:"method"`
+	APIVersion string `json:"api_version"`
+	IPAddress  string `json:"ip_address"`
+	// HTTPStatus is the receiver's status line, such as "500 Internal
+	// Server Error", and empty when no response was received.
+	HTTPStatus string `json:"http_status"`
+	// Error describes why the attempt failed, such as a timeout or a
+	// connection error.
+	Error string `json:"error"`
+	// RequestHeaders are the headers Convoy sent, with Authorization
+	// redacted.
+	RequestHeaders  AttemptHeader `json:"request_http_header"`
+	ResponseHeaders AttemptHeader `json:"response_http_header"`
+	// ResponseBody is the body returned by the receiver.
+	ResponseBody string    `json:"response_data"`
+	Status       bool      `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AttemptHeader holds the headers of a delivery attempt. Convoy stores one
+// value per header, joining repeated headers, and sends each as a string;
+// an array of values is accepted as well.
+type AttemptHeader map[string][]string
+
+func (h *AttemptHeader) UnmarshalJSON(data []byte) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if values == nil {
+		*h = nil
+		return nil
+	}
+	header := make(AttemptHeader, len(values))
+	for name, raw := range values {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			header[name] = []string{value}
+			continue
+		}
+		var list []string
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return fmt.Errorf("header %s: %w", name, err)
+		}
+		header[name] = list
+	}
+	*h = header
+	return nil
+}
+
+// StatusCode returns the receiver's status code, or 0 if the attempt got no
+// response.
+func (a DeliveryAttempt) StatusCode() int {
+	code, _, _ := strings.Cut(a.HTTPStatus, " ")
+	n, _ := strconv.Atoi(code)
+	return n
+}
+
+// GetDeliveryAttempts returns the attempts made for a delivery, oldest
+// first.
+func (we *webhookData) GetDeliveryAttempts(projectID, deliveryID string) ([]DeliveryAttempt, error) {
+	var attempts struct {
+		Data []DeliveryAttempt `json:"data"`
+	}
+	err := we.do(request{
+		op:      "GetDeliveryAttempts",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/eventdeliveries/", deliveryID, "/deliveryattempts"),
+		timeout: 2 * time.Second,
+	}, &attempts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attempt := range attempts.Data {
+		redactAuthorization(attempt.RequestHeaders)
+		redactAuthorization(attempt.ResponseHeaders)
+	}
+	return attempts.Data, nil
+}
+
+func redactAuthorization(header map[string][]string) {
+	for name := range header {
+		if strings.EqualFold(name, "Authorization") {
+			header[name] = []string{redacted}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package convoy
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+const attemptsJSON = `{"status":true,"message":"delivery attempts fetched","data":[{
+	"uid":"01HQ8B2C4D5E6F7G8H9J0K1L2M","url":"https://billing.example.com/hook","method":"POST",
+	"api_version":"2024-01-01","endpoint_id":"ep-1","project_id":"proj-1",
+	"msg_id":"dl-1","ip_address":"203.0.113.7",
+	"request_http_header":{"Content-Type":"application/json","Authorization":"Bearer whsec_live",
+		"User-Agent":"Convoy/v24.1.4","X-Convoy-Signature":"t=1709287200,v1=abc="},
+	"response_http_header":{"Content-Type":"text/plain; charset=utf-8","Access-Control-Allow-Origin":"https://app.example.com",
+		"Vary":["Origin","Accept-Encoding"]},
+	"http_status":"403 Forbidden","response_data":"origin not allowed",
+	"error":"","status":false,
+	"created_at":"2024-03-01T10:00:00.481Z","updated_at":"2024-03-01T10:00:00.481Z","deleted_at":null}]}`
+
+func TestGetDeliveryAttemptsDecode(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusOK, attemptsJSON))
+	attempts, err := client.GetDeliveryAttempts("", "dl-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 1 {
+		t.Fatalf("decoded %d attempts, want 1", len(attempts))
+	}
+	a := attempts[0]
+	if a.DeliveryID != "dl-1" || a.IPAddress != "203.0.113.7" || a.StatusCode() != http.StatusForbidden || a.Status {
+		t.Errorf("attempt = %+v", a)
+	}
+	if a.ResponseBody != "origin not allowed" {
+		t.Errorf("response body = %q", a.ResponseBody)
+	}
+	if !a.CreatedAt.Equal(time.Date(2024, 3, 1, 10, 0, 0, 481e6, time.UTC)) {
+		t.Errorf("created at = %v", a.CreatedAt)
+	}
+
+	for name, want := range map[string][]string{
+		"Authorization":      {redacted},
+		"X-Convoy-Signature": {"t=1709287200,v1=abc="},
+	} {
+		if got := a.RequestHeaders[name]; !slices.Equal(got, want) {
+			t.Errorf("request header %s = %q, want %q", name, got, want)
+		}
+	}
+	for name, want := range map[string][]string{
+		"Access-Control-Allow-Origin": {"https://app.example.com"},
+		"Vary":                        {"Origin", "Accept-Encoding"},
+	} {
+		if got := a.ResponseHeaders[name]; !slices.Equal(got, want) {
+			t.Errorf("response header %s = %q, want %q", name, got, want)
+		}
+	}
+	if http.Header(a.ResponseHeaders).Get("Content-Type") != "text/plain; charset=utf-8" {
+		t.Errorf("response headers = %v", a.ResponseHeaders)
+	}
+}
@@ -0,0 +1,90 @@
+package convoy
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes the delay before a retry. attempt is 0 before the first
+// retry. Implementations must be safe for concurrent use.
+type Backoff interface {
+	NextInterval(attempt int) time.Duration
+}
+
+// ConstantBackoff waits Interval before every retry.
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+func (b ConstantBackoff) NextInterval(int) time.Duration {
+	return b.Interval
+}
+
+// LinearBackoff waits Initial, then Initial+Step, Initial+2*Step and so on,
+// capped at Max when Max is positive.
+type LinearBackoff struct {
+	Initial time.Duration
+	Step    time.Duration
+	Max     time.Duration
+}
+
+func (b LinearBackoff) NextInterval(attempt int) time.Duration {
+	return capDelay(b.Initial+time.Duration(attempt)*b.Step, b.Max)
+}
+
+// ExponentialBackoff doubles the delay from Base on every retry, capped at
+// Max when Max is positive. It is the default, with a 500ms base and a 10s
+// cap.
+type ExponentialBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b ExponentialBackoff) NextInterval(attempt int) time.Duration {
+	return capDelay(shiftDelay(b.Base, attempt), b.Max)
+}
+
+// DecorrelatedJitterBackoff picks a random delay between Base and three
+// times the previous upper bound, capped at Max when Max is positive. It
+// spreads out clients retrying at the same time. The upper bound is derived
+// from attempt rather than the previous delay, so one value can be shared by
+// concurrent requests.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b DecorrelatedJitterBackoff) NextInterval(attempt int) time.Duration {
+	upper := b.Base
+	for i := 0; i < attempt && (b.Max <= 0 || upper < b.Max); i++ {
+		if upper > maxDelay/3 {
+			upper = maxDelay
+			break
+		}
+		upper *= 3
+	}
+	upper = capDelay(upper, b.Max)
+	if upper <= b.Base {
+		return upper
+	}
+	return b.Base + rand.N(upper-b.Base+1)
+}
+
+const maxDelay = time.Duration(1<<63 - 1)
+
+func shiftDelay(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	if attempt >= 63 || base > maxDelay>>attempt {
+		return maxDelay
+	}
+	return base << attempt
+}
+
+func capDelay(d, limit time.Duration) time.Duration {
+	if limit > 0 && d > limit {
+		return limit
+	}
+	return d
+}
@@ -0,0 +1,61 @@
+package convoy
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func intervals(b Backoff, n int) []time.Duration {
+	var seq []time.Duration
+	for attempt := range n {
+		seq = append(seq, b.NextInterval(attempt))
+	}
+	return seq
+}
+
+func TestBackoffSequences(t *testing.T) {
+	ms := time.Millisecond
+	for _, c := range []struct {
+		name    string
+		backoff Backoff
+		want    []time.Duration
+	}{
+		{"constant", ConstantBackoff{Interval: 200 * ms}, []time.Duration{200 * ms, 200 * ms, 200 * ms, 200 * ms}},
+		{"linear", LinearBackoff{Initial: 100 * ms, Step: 150 * ms, Max: 500 * ms}, []time.Duration{100 * ms, 250 * ms, 400 * ms, 500 * ms, 500 * ms}},
+		{"linear uncapped", LinearBackoff{Initial: 100 * ms, Step: 100 * ms}, []time.Duration{100 * ms, 200 * ms, 300 * ms}},
+		{"exponential", ExponentialBackoff{Base: 500 * ms, Max: 10 * time.Second}, []time.Duration{500 * ms, time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}},
+		{"exponential zero base", ExponentialBackoff{}, []time.Duration{0, 0, 0}},
+	} {
+		if got := intervals(c.backoff, len(c.want)); !slices.Equal(got, c.want) {
+			t.Errorf("%s: intervals = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	if got := (ExponentialBackoff{Base: time.Second}).NextInterval(100); got != maxDelay {
+		t.Errorf("exponential after 100 attempts = %v, want it saturated at %v", got, maxDelay)
+	}
+}
+
+func TestDecorrelatedJitterBackoffBounds(t *testing.T) {
+	b := DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Max: 5 * time.Second}
+	uppers := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, 2700 * time.Millisecond, 5 * time.Second, 5 * time.Second}
+	for attempt, upper := range uppers {
+		seen := map[time.Duration]bool{}
+		for range 200 {
+			d := b.NextInterval(attempt)
+			if d < b.Base || d > upper {
+				t.Fatalf("attempt %d: interval %v outside [%v, %v]", attempt, d, b.Base, upper)
+			}
+			seen[d] = true
+		}
+		if attempt > 0 && len(seen) < 2 {
+			t.Errorf("attempt %d: always %v, want jitter", attempt, b.NextInterval(attempt))
+		}
+	}
+
+	uncapped := DecorrelatedJitterBackoff{Base: time.Second}
+	if d := uncapped.NextInterval(100); d < time.Second {
+		t.Errorf("uncapped after 100 attempts = %v, want no overflow", d)
+	}
+}
@@ -0,0 +1,94 @@
+package convoy
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchResult is the outcome of one item of a batch operation. Index is the
+// item's position in the input and ID the resource it created or acted on;
+// ID is empty when creation failed.
+type BatchResult struct {
+	Index int
+	ID    string
+	Err   error
+}
+
+// Failures returns the results that carry an error, in input order, so the
+// failed items can be retried on their own.
+func Failures(results []BatchResult) []BatchResult {
+	var failed []BatchResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// CreateEvents creates the events concurrently and returns one result per
+// webhook, holding the event ID or the error that item failed with. A
+// failing item doesn't stop the others.
+func (we *webhookData) CreateEvents(ctx context.Context, projectID string, webhooks []*Webhook) []BatchResult {
+	return runBatch(ctx, len(webhooks), func(ctx context.Context, i int) (string, error) {
+		event, err := we.createEvent(ctx, projectID, webhooks[i])
+		if err != nil {
+			return "", err
+		}
+		return event.Data.UID, nil
+	})
+}
+
+// CreateEndpoints creates the endpoints concurrently and returns one result
+// per params, holding the endpoint ID or the error that item failed with.
+func (we *webhookData) CreateEndpoints(ctx context.Context, projectID string, params []UpsertEndpointParams) []BatchResult {
+	return runBatch(ctx, len(params), func(ctx context.Context, i int) (string, error) {
+		endpoint, err := we.createEndpoint(ctx, projectID, params[i])
+		if err != nil {
+			return "", err
+		}
+		return endpoint.Data.Uid, nil
+	})
+}
+
+// RetryEventDeliveries retries the deliveries concurrently and returns one
+// result per ID.
+func (we *webhookData) RetryEventDeliveries(ctx context.Context, projectID string, deliveryIDs []string) []BatchResult {
+	results := runBatch(ctx, len(deliveryIDs), func(ctx context.Context, i int) (string, error) {
+		return "", we.retryEventDelivery(ctx, projectID, deliveryIDs[i])
+	})
+	// Skipped deliveries are reported by ID too.
+	for i := range results {
+		results[i].ID = deliveryIDs[i]
+	}
+	return results
+}
+
+// runBatch calls fn for each of n items with at most bulkConcurrency in
+// flight. Items not started before ctx ends fail with its error.
+func runBatch(ctx context.Context, n int, fn func(ctx context.Context, i int) (string, error)) []BatchResult {
+	results := make([]BatchResult, n)
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for i := range results {
+		results[i].Index = i
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(result *BatchResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result.ID, result.Err = fn(ctx, result.Index)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results
+}
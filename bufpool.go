@@ -0,0 +1,40 @@
+package convoy
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// maxPooledBuffer keeps unusually large bodies from pinning memory in the
+// pool.
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns buf to the pool. Its contents must no longer be used.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}
+}
+
+// readBody reads r to the end like io.ReadAll, but grows a pooled buffer
+// instead of a fresh slice and returns a copy of exactly the bytes read, for
+// bodies that outlive the call.
+func readBody(r io.Reader) ([]byte, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
@@ -0,0 +1,53 @@
+package convoy
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+const endpointJSON = `{"status":true,"message":"Endpoint fetched successfully","data":{
+	"uid":"01HQ7A3V3Z1N6F8Y2T1K4J5M6P","name":"billing","url":"https://billing.example.com/hook",
+	"owner_id":"owner-1","project_id":"proj-1","status":"active","support_email":"ops@example.com",
+	"description":"invoices","http_timeout":10,"rate_limit":5000,"rate_limit_duration":60,
+	"secrets":[{"uid":"sec-1","value":"whsec_0123456789abcdef","created_at":"2024-03-01T10:00:00.123456Z","updated_at":"2024-03-01T10:00:00Z","expires_at":null}],
+	"created_at":"2024-03-01T10:00:00.123456Z","updated_at":"2024-03-02T11:30:00Z","deleted_at":null}}`
+
+// newStubClient returns a client whose requests are answered by fn without
+// touching the network.
+func newStubClient(tb testing.TB, fn roundTripFunc, opts ...Option) *webhookService {
+	tb.Helper()
+	client, err := NewWebhook("https://convoy.example.com", "key", "project", append([]Option{WithTransport(fn)}, opts...)...)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return client
+}
+
+func stubResponse(status int, body string) roundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+}
+
+func BenchmarkGetEndpoint(b *testing.B) {
+	client := newStubClient(b, stubResponse(http.StatusOK, endpointJSON))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.GetEndpoint("", "ep-1"); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
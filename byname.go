@@ -0,0 +1,91 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrAmbiguousEndpointName is returned when several endpoints share the name
+// passed to CreateEventByEndpointName.
+var ErrAmbiguousEndpointName = errors.New("convoy: endpoint name matches several endpoints")
+
+// DefaultEndpointNameTTL is how long CreateEventByEndpointName remembers the
+// endpoint a name resolved to.
+const DefaultEndpointNameTTL = 5 * time.Minute
+
+// endpointNames caches name to endpoint ID resolutions. It is shared by
+// clients derived through ForProject.
+type endpointNames struct {
+	mu      sync.Mutex
+	entries map[string]endpointNameEntry
+}
+
+type endpointNameEntry struct {
+	id      string
+	expires time.Time
+}
+
+// CreateEventByEndpointName creates an event of eventType carrying data for
+// the endpoint named endpointName. The name must match exactly one endpoint
+// of the project; the resolved ID is cached for the TTL set with
+// WithEndpointNameTTL, so a renamed endpoint may keep receiving events by
+// its old name until then.
+func (we *webhookData) CreateEventByEndpointName(projectID, endpointName string, data any, eventType string) (*EventResponse, error) {
+	ctx := context.Background()
+	endpointID, err := we.resolveEndpointName(ctx, projectID, endpointName)
+	if err != nil {
+		return nil, err
+	}
+
+	return we.createEvent(ctx, projectID, &Webhook{
+		Data: WebhookData{
+			Data:       data,
+			EventType:  eventType,
+			EndpointID: endpointID,
+		},
+	})
+}
+
+func (we *webhookData) resolveEndpointName(ctx context.Context, projectID, name string) (string, error) {
+	key := we.projectPath(projectID, "/", name)
+	now := we.clock.Now()
+
+	we.endpointNames.mu.Lock()
+	entry, ok := we.endpointNames.entries[key]
+	we.endpointNames.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.id, nil
+	}
+
+	// The name filter is a substring search, so keep exact matches only.
+	endpoints, err := we.listAllEndpoints(ctx, projectID, EndpointQuery{Name: name})
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, endpoint := range endpoints {
+		if endpoint.Name == name {
+			ids = append(ids, endpoint.UID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%w: no endpoint named %q", ErrNotFound, name)
+	case 1:
+	default:
+		return "", fmt.Errorf("%w: %q matches %v", ErrAmbiguousEndpointName, name, ids)
+	}
+
+	if we.endpointNameTTL > 0 {
+		we.endpointNames.mu.Lock()
+		if we.endpointNames.entries == nil {
+			we.endpointNames.entries = make(map[string]endpointNameEntry)
+		}
+		we.endpointNames.entries[key] = endpointNameEntry{id: ids[0], expires: now.Add(we.endpointNameTTL)}
+		we.endpointNames.mu.Unlock()
+	}
+	return ids[0], nil
+}
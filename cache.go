@@ -0,0 +1,114 @@
+package convoy
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores GET responses for conditional requests, see WithCache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (CachedResponse, bool)
+	Set(key string, response CachedResponse)
+}
+
+// CachedResponse is a response body with the validators Convoy sent for it.
+type CachedResponse struct {
+	ETag         string
+	LastModified string
+	// Expires is when the response stops being fresh according to its
+	// Cache-Control max-age. Until then it is used without a request.
+	Expires time.Time
+	Body    []byte
+}
+
+// MemoryCache is an in-process Cache without eviction, meant for a bounded
+// set of hot resources. The zero value is ready to use.
+type MemoryCache struct {
+	mu        sync.RWMutex
+	responses map[string]CachedResponse
+}
+
+func (c *MemoryCache) Get(key string) (CachedResponse, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	response, ok := c.responses[key]
+	return response, ok
+}
+
+func (c *MemoryCache) Set(key string, response CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.responses == nil {
+		c.responses = make(map[string]CachedResponse)
+	}
+	c.responses[key] = response
+}
+
+// cacheKey identifies the response to a GET request. It starts with a
+// fingerprint of the credential the request is sent with, so that clients
+// sharing a Cache never read responses fetched with another's access.
+func (we *webhookData) cacheKey(r request) string {
+	credential := r.header.Get("Authorization")
+	if !r.noAuth {
+		credential = we.token(r.auth)
+	}
+	sum := sha256.Sum256([]byte(credential))
+	key := hex.EncodeToString(sum[:8]) + " " + cmp.Or(r.baseURL, we.url) + r.path
+	if len(r.query) == 0 {
+		return key
+	}
+	return key + "?" + r.query.Encode()
+}
+
+// conditionalHeader returns header with the validators of cached added.
+func conditionalHeader(header http.Header, cached CachedResponse) http.Header {
+	conditional := header.Clone()
+	if conditional == nil {
+		conditional = make(http.Header)
+	}
+	if cached.ETag != "" {
+		conditional.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		conditional.Set("If-Modified-Since", cached.LastModified)
+	}
+	return conditional
+}
+
+// cacheableResponse returns the cache entry for a response with the given
+// headers and body, and false if it may not be cached.
+func cacheableResponse(header http.Header, body []byte, now time.Time) (CachedResponse, bool) {
+	response := CachedResponse{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Body:         body,
+	}
+	noCache := false
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			return CachedResponse{}, false
+		case "no-cache":
+			noCache = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				response.Expires = now.Add(time.Duration(seconds) * time.Second)
+			}
+		}
+	}
+	if noCache {
+		response.Expires = time.Time{}
+	}
+	if response.ETag == "" && response.LastModified == "" && response.Expires.IsZero() {
+		return CachedResponse{}, false
+	}
+	return response, true
+}
@@ -0,0 +1,51 @@
+package convoy
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCacheKeyedByCredential(t *testing.T) {
+	var requests int
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		name := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":  []string{"application/json"},
+				"Cache-Control": []string{"max-age=60"},
+			},
+			Body:    io.NopCloser(strings.NewReader(`{"status":true,"data":{"uid":"ep-1","name":"` + name + `"}}`)),
+			Request: r,
+		}, nil
+	})
+	client := newStubClient(t, transport, WithProjectKey("key-a"), WithCache(&MemoryCache{}))
+	other, err := client.Clone(WithProjectKey("key-b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		client *webhookService
+		want   string
+	}{
+		{client, "key-a"},
+		{other, "key-b"},
+		{client, "key-a"},
+		{other, "key-b"},
+	} {
+		resp, err := c.client.GetEndpoint("", "ep-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Data.Name != c.want {
+			t.Errorf("client with %s read the response fetched with %s", c.want, resp.Data.Name)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("sent %d requests, want 1 per credential", requests)
+	}
+}
@@ -0,0 +1,46 @@
+package convoy
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// CanonicalJSON encodes v deterministically, so the bytes can be signed
+// before sending and verified against the raw body on receipt:
+//
+//   - object keys, including struct fields, are sorted by their UTF-8 bytes;
+//   - there is no insignificant whitespace;
+//   - numbers keep the text encoding/json gives them;
+//   - strings are escaped as encoding/json does, except that <, > and & are
+//     left as is.
+func CanonicalJSON(v any) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decoding into maps sorts the keys on re-encoding.
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// marshalEvent encodes an event request body, canonically when
+// WithCanonicalJSON is set.
+func (we *webhookData) marshalEvent(v any) ([]byte, error) {
+	if we.canonicalJSON {
+		return CanonicalJSON(v)
+	}
+	return json.Marshal(v)
+}
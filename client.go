@@ -0,0 +1,384 @@
+package convoy
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DryRunMessage is the message carried by the synthetic responses returned
+// for mutating calls while the client is in dry-run mode.
+const DryRunMessage = "dry run: request was not sent"
+
+var dryRunResponse = fmt.Sprintf(
+	`{"status":true,"message":%q,"data":{"uid":"dry-run","status":"dry-run"}}`,
+	DryRunMessage,
+)
+
+type request struct {
+	ctx context.Context
+	// baseURL overrides the client's base URL, for failover.
+	baseURL string
+	// op names the client method issuing the request, for metrics.
+	op     string
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   []byte
+	// stream, when set, is sent instead of body. It can be read only once,
+	// so the request is never retried.
+	stream  io.Reader
+	timeout time.Duration
+	// accept overrides the status codes treated as success for this call.
+	accept []int
+	auth   credential
+	// noAuth leaves the Authorization header to the caller.
+	noAuth bool
+	// idempotencyKey is sent as the Idempotency-Key header and makes a
+	// non-GET request eligible for retries.
+	idempotencyKey string
+	// idempotent makes a non-GET request eligible for retries without a
+	// key, for operations that are harmless to repeat.
+	idempotent bool
+	// notModified accepts a 304 response to a conditional request.
+	notModified bool
+	// onAttempt, when set, is called before and after every attempt.
+	onAttempt func(EventAttempt)
+}
+
+// do sends r and decodes a successful response into out. A nil out discards
+// the body and a *[]byte receives it unparsed.
+func (we *webhookData) do(r request, out any) (err error) {
+	we.metrics.IncRequest(r.op)
+	defer func() {
+		if err != nil {
+			we.metrics.IncError(r.op)
+		}
+	}()
+
+	if r.ctx == nil {
+		r.ctx = context.Background()
+	}
+	if we.inFlight != nil {
+		select {
+		case we.inFlight <- struct{}{}:
+			defer func() { <-we.inFlight }()
+		case <-r.ctx.Done():
+			return r.ctx.Err()
+		}
+	}
+
+	var (
+		cached   CachedResponse
+		hasCache bool
+	)
+	if we.cache != nil && r.method == http.MethodGet {
+		cached, hasCache = we.cache.Get(we.cacheKey(r))
+		if hasCache && we.clock.Now().Before(cached.Expires) {
+			return we.decodeBytes(r, http.StatusOK, cached.Body, out)
+		}
+		if hasCache {
+			r.header = conditionalHeader(r.header, cached)
+			r.notModified = true
+		}
+	}
+
+	resp, err := we.roundTripFailover(r)
+	if err != nil {
+		return err
+	}
+	defer we.closeBody(resp.Body)
+
+	switch {
+	case r.notModified && resp.StatusCode == http.StatusNotModified:
+		return we.decodeBytes(r, resp.StatusCode, cached.Body, out)
+	case we.cache != nil && r.method == http.MethodGet:
+		body := &countingReader{r: resp.Body}
+		raw, err := readBody(body)
+		if err != nil {
+			return malformedResponse(r, resp.StatusCode, body.n, err)
+		}
+		if entry, ok := cacheableResponse(resp.Header, raw, we.clock.Now()); ok {
+			we.cache.Set(we.cacheKey(r), entry)
+		}
+		return we.decodeBytes(r, resp.StatusCode, raw, out)
+	}
+	return we.decode(r, resp.StatusCode, resp.Body, out)
+}
+
+// decode reads a successful response body into out, as described on do,
+// through a pooled buffer.
+func (we *webhookData) decode(r request, status int, src io.Reader, out any) error {
+	if out == nil {
+		return nil
+	}
+	body := &countingReader{r: src}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(body); err != nil {
+		return malformedResponse(r, status, body.n, err)
+	}
+	return we.decodeBytes(r, status, buf.Bytes(), out)
+}
+
+// decodeBytes is decode for a body already read. data is not retained. An
+// empty body, as sent with 204 No Content, leaves out untouched apart from
+// marking a response envelope as succeeded when the status is 2xx.
+func (we *webhookData) decodeBytes(r request, status int, data []byte, out any) error {
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*out = bytes.Clone(data)
+		return nil
+	default:
+		if len(data) == 0 {
+			if e, ok := out.(succeeder); ok && status >= 200 && status < 300 {
+				e.setSucceeded()
+			}
+			return nil
+		}
+		if err := we.unmarshal(data, out); err != nil {
+			return malformedResponse(r, status, int64(len(data)), err)
+		}
+		return nil
+	}
+}
+
+// unmarshal decodes data into out as configured by WithStrictDecoding and
+// WithUseNumber.
+func (we *webhookData) unmarshal(data []byte, out any) error {
+	return jsonDecoding{strict: we.strictDecoding, useNumber: we.useNumber}.unmarshal(data, out)
+}
+
+// jsonDecoding is the decoder configuration of a client.
+type jsonDecoding struct {
+	strict    bool
+	useNumber bool
+}
+
+// unmarshal decodes data into out. It accepts every timestamp format Convoy
+// has emitted and a bare array for a Page, and converts times to UTC.
+func (d jsonDecoding) unmarshal(data []byte, out any) error {
+	err := d.decode(data, out)
+	var (
+		timeErr *time.ParseError
+		typeErr *json.UnmarshalTypeError
+	)
+	if errors.As(err, &timeErr) || errors.As(err, &typeErr) && typeErr.Value == "array" && isPage(typeErr.Type) {
+		normalized, nerr := normalize(data, reflect.TypeOf(out))
+		if nerr != nil {
+			return nerr
+		}
+		err = d.decode(normalized, out)
+	}
+	if err == nil {
+		toUTC(reflect.ValueOf(out))
+	}
+	return err
+}
+
+func (d jsonDecoding) decode(data []byte, out any) error {
+	if !d.strict && !d.useNumber {
+		return json.Unmarshal(data, out)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if d.strict {
+		dec.DisallowUnknownFields()
+	}
+	if d.useNumber {
+		dec.UseNumber()
+	}
+	return dec.Decode(out)
+}
+
+// malformedResponse wraps an error reading or decoding the body of an
+// accepted response in ErrMalformedResponse.
+func malformedResponse(r request, status int, n int64, err error) error {
+	hint := ""
+	if r.method != http.MethodGet {
+		hint = "; the request may have been applied, retry only with an idempotency key"
+	}
+	return fmt.Errorf("%w: %s %s returned %d, failed after %d bytes%s: %w",
+		ErrMalformedResponse, r.method, r.path, status, n, hint, err)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// roundTrip sends r until it gets an accepted response, retrying while
+// retryable allows. Rejected responses are returned as *APIError, and the
+// final error is wrapped in *RetryError when more than one attempt was made.
+func (we *webhookData) roundTrip(r request) (*http.Response, error) {
+	start := we.clock.Now()
+	var failures []error
+	for attempt := 0; ; attempt++ {
+		if r.onAttempt != nil {
+			r.onAttempt(EventAttempt{Op: r.op, Attempt: attempt + 1})
+		}
+		sent := we.clock.Now()
+		resp, err := we.send(r)
+		latency := we.clock.Now().Sub(sent)
+		we.metrics.ObserveLatency(r.op, latency)
+		if r.onAttempt != nil {
+			outcome := EventAttempt{Op: r.op, Attempt: attempt + 1, Completed: true, Latency: latency, Err: err}
+			if err == nil {
+				outcome.StatusCode = resp.StatusCode
+			}
+			r.onAttempt(outcome)
+		}
+
+		if err == nil && we.accepts(r, resp.StatusCode) {
+			return resp, nil
+		}
+		retry := attempt < we.maxRetries && we.retryable(r, resp, err, attempt+1)
+		if err == nil {
+			err = newAPIError(resp)
+			we.closeBody(resp.Body)
+		}
+		failures = append(failures, err)
+
+		if !retry {
+			if attempt == 0 {
+				return nil, err
+			}
+			return nil, &RetryError{
+				Attempts: attempt + 1,
+				Elapsed:  we.clock.Now().Sub(start),
+				Errors:   failures,
+			}
+		}
+		we.metrics.IncRetry(r.op)
+
+		if err := we.clock.Sleep(r.ctx, we.backoff.NextInterval(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (we *webhookData) accepts(r request, code int) bool {
+	if r.notModified && code == http.StatusNotModified {
+		return true
+	}
+	if len(r.accept) > 0 {
+		return slices.Contains(r.accept, code)
+	}
+	if codes, ok := we.successCodes[r.method]; ok {
+		return slices.Contains(codes, code)
+	}
+	return code >= 200 && code < 300
+}
+
+func (we *webhookData) send(r request) (*http.Response, error) {
+	// A *bytes.Reader body gets a GetBody, so it can be replayed on
+	// redirects as well as on retries.
+	var body io.Reader
+	switch {
+	case r.stream != nil:
+		body = r.stream
+	case r.body != nil:
+		body = bytes.NewReader(r.body)
+	}
+
+	req, err := http.NewRequestWithContext(r.ctx, r.method, fmt.Sprint(cmp.Or(r.baseURL, we.url), r.path), body)
+	if err != nil {
+		return nil, err
+	}
+	if r.query != nil {
+		req.URL.RawQuery = r.query.Encode()
+	}
+	for name, values := range r.header {
+		req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+	if !r.noAuth {
+		req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.token(r.auth)))
+	}
+	req.Header.Set("Accept", we.accept)
+	req.Header.Set("User-Agent", we.userAgent())
+	if r.idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", r.idempotencyKey)
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", we.contentType)
+	}
+
+	if we.dryRun && r.method != http.MethodGet {
+		logged := we.logBody(r.body)
+		if r.stream != nil {
+			logged = "(streamed body not logged)"
+		}
+		we.logger.Info("dry run",
+			"method", r.method,
+			"url", req.URL.String(),
+			"header", redactHeader(req.Header),
+			"body", logged,
+		)
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(dryRunResponse)),
+			Request:    req,
+		}, nil
+	}
+
+	client := &http.Client{
+		Transport:     we.transport,
+		Timeout:       we.timeoutFor(r),
+		CheckRedirect: we.redirectPolicy.checkRedirect,
+	}
+	return client.Do(req)
+}
+
+// timeoutFor returns the timeout of r: the one configured for its method,
+// else the client-wide one, else the method's built-in default.
+func (we *webhookData) timeoutFor(r request) time.Duration {
+	if d, ok := we.opTimeouts[r.op]; ok {
+		return d
+	}
+	if we.timeout > 0 {
+		return we.timeout
+	}
+	return r.timeout
+}
+
+// mergeHeaders returns the union of base and override, with values from
+// override replacing those of base for the same header name.
+func mergeHeaders(base, override http.Header) http.Header {
+	if len(base) == 0 {
+		return override
+	}
+	merged := make(http.Header, len(base)+len(override))
+	for name, values := range base {
+		merged[http.CanonicalHeaderKey(name)] = values
+	}
+	for name, values := range override {
+		merged[http.CanonicalHeaderKey(name)] = values
+	}
+	return merged
+}
+
+func (we *webhookData) closeBody(body io.ReadCloser) {
+	if err := body.Close(); err != nil {
+		we.logger.Error("error closing response body", "err", err)
+	}
+}
@@ -0,0 +1,81 @@
+package convoy
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCreateEndpointSuccessCodes(t *testing.T) {
+	params := UpsertEndpointParams{Name: "billing", URL: "https://billing.example.com/hook"}
+	body := `{"status":true,"message":"Endpoint created successfully","data":{"uid":"ep-1","name":"billing"}}`
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client := newStubClient(t, stubResponse(status, body))
+			created, err := client.CreateEndpoint("", params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if created.Data.Uid != "ep-1" || !created.Succeeded() {
+				t.Errorf("created = %+v, want ep-1", created)
+			}
+		})
+	}
+
+	client := newStubClient(t, stubResponse(http.StatusAccepted, body), WithSuccessStatusCodes(http.MethodPost, http.StatusCreated))
+	_, err := client.CreateEndpoint("", params)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusAccepted {
+		t.Errorf("err = %v, want a 202 APIError once only 201 is accepted", err)
+	}
+}
+
+func TestCreateEventKeepsCallerContentType(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		defaults http.Header
+		headers  map[string][]string
+		want     string
+	}{
+		{"default", nil, nil, "application/json"},
+		{"caller", nil, map[string][]string{"Content-Type": {"application/json; charset=utf-8"}}, "application/json; charset=utf-8"},
+		{"caller lowercase", nil, map[string][]string{"content-type": {"application/vnd.acme+json"}}, "application/vnd.acme+json"},
+		{"over event defaults", http.Header{"Content-Type": {"application/cloudevents+json"}},
+			map[string][]string{"content-type": {"application/vnd.acme+json"}}, "application/vnd.acme+json"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+				got = r.Header.Values("Content-Type")
+				return stubResponse(http.StatusCreated, `{"status":true}`)(r)
+			}, WithDefaultEventHeaders(c.defaults))
+			err := client.CreateEvent("", &Webhook{
+				Data:    WebhookData{EndpointID: "ep-1", EventType: "invoice.paid", Data: map[string]any{"id": 1}},
+				Headers: c.headers,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got[0] != c.want {
+				t.Errorf("Content-Type = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNoContentResponses(t *testing.T) {
+	for _, status := range []int{http.StatusNoContent, http.StatusOK} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client := newStubClient(t, stubResponse(status, ""))
+			if _, err := client.DeleteEndpoint("", "ep-1"); err != nil {
+				t.Errorf("DeleteEndpoint: %v", err)
+			}
+			if _, err := client.TogglePause("", "ep-1"); err != nil {
+				t.Errorf("TogglePause: %v", err)
+			}
+			if err := client.DeleteSubscription("", "sub-1"); err != nil {
+				t.Errorf("DeleteSubscription: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package convoy
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// rotatingPublisher returns a func that creates an event, rotating the API
+// key every 64 calls, for use from many goroutines at once.
+func rotatingPublisher(tb testing.TB) func() {
+	keys := []string{"key-0", "key-1", "key-2"}
+	client := newStubClient(tb, func(r *http.Request) (*http.Response, error) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !strings.HasPrefix(key, "key-") {
+			tb.Errorf("request sent with key %q", key)
+		}
+		return stubResponse(http.StatusCreated, `{"status":true,"data":{"uid":"ev-1"}}`)(r)
+	}, WithProjectKey(keys[0]))
+	event := &Webhook{Data: WebhookData{EndpointID: "ep-1", EventType: "invoice.paid", Data: map[string]any{"id": 1}}}
+
+	var n atomic.Int64
+	return func() {
+		if i := n.Add(1); i%64 == 0 {
+			client.SetAPIKey(keys[i/64%int64(len(keys))])
+		}
+		if err := client.CreateEvent("", event); err != nil {
+			tb.Error(err)
+		}
+	}
+}
+
+// TestCreateEventConcurrent is meant to be run with -race.
+func TestCreateEventConcurrent(t *testing.T) {
+	publish := rotatingPublisher(t)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				publish()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkCreateEventConcurrent publishes from many goroutines while the API
+// key rotates. Run it with -race to check the client is safe for concurrent
+// use.
+func BenchmarkCreateEventConcurrent(b *testing.B) {
+	publish := rotatingPublisher(b)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			publish()
+		}
+	})
+}
@@ -1,26 +1,91 @@
 package convoy
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 type WebhookInterface interface {
-	GetEndpoint(projectID, endpointID string) (*Endpoint, error)
+	GetEndpoint(projectID, endpointID string, opts ...GetEndpointOption) (*Endpoint, error)
 	CreateEndpoint(projectID string, params UpsertEndpointParams) (*CreateEndpointResponse, error)
+	CreateEndpoints(ctx context.Context, projectID string, params []UpsertEndpointParams) []BatchResult
 	UpdateEndpoint(projectID, endpointID string, params UpsertEndpointParams) (*EndpointResponse, error)
+	UpdateEndpointPatch(projectID, endpointID string, patch map[string]any) (*EndpointResponse, error)
+	UpsertEndpoint(projectID string, params UpsertEndpointParams, match EndpointMatch) (string, error)
+	TransferEndpointOwner(projectID, endpointID, newOwnerID string) (*EndpointResponse, error)
+	UpdateEndpointURL(projectID, endpointID, newURL string) (*EndpointResponse, error)
 	DeleteEndpoint(projectID, endpointID string) (*EndpointResponse, error)
+	SafeDeleteEndpoint(ctx context.Context, projectID, endpointID string) error
 	TogglePause(projectID, endpointID string) (string, error)
+	TogglePauseContext(ctx context.Context, projectID, endpointID string) (string, error)
+	PauseEndpoint(projectID, endpointID string) (string, error)
+	PauseEndpointContext(ctx context.Context, projectID, endpointID string) (string, error)
+	ActivateEndpoint(projectID, endpointID string) (string, error)
+	ActivateEndpointContext(ctx context.Context, projectID, endpointID string) (string, error)
+	SetEndpointPaused(ctx context.Context, projectID, endpointID string, paused bool) (string, error)
+	ResetEndpointCircuitBreaker(projectID, endpointID string) (string, error)
+	ListEndpoints(projectID string, query EndpointQuery) (*EndpointList, error)
+	GetEndpoints(ctx context.Context, projectID string, ids []string) ([]EndpointData, error)
+	EndpointExists(projectID, endpointID string) (bool, error)
+	StaleEndpoints(ctx context.Context, projectID string, since time.Time) ([]EndpointData, error)
+	PauseEndpointsByOwner(ctx context.Context, projectID, ownerID string) ([]BatchResult, error)
+	ActivateEndpointsByOwner(ctx context.Context, projectID, ownerID string) ([]BatchResult, error)
 	CreateEvent(projectID string, webhookData *Webhook) error
+	CreateEvents(ctx context.Context, projectID string, webhooks []*Webhook) []BatchResult
+	CreateEventByEndpointName(projectID, endpointName string, data any, eventType string) (*EventResponse, error)
+	CreateEventFromReader(ctx context.Context, projectID string, meta WebhookData, payload io.Reader) (*EventResponse, error)
+	CreateRawEvent(projectID string, body []byte, contentType string, opts RawEventOptions) (*EventResponse, error)
+	Publish(ctx context.Context, ownerID, eventType string, data any) (string, error)
+	StartPublisher(ctx context.Context, opts PublisherOptions) *Publisher
 	GetEndpointEventDeliveries(projectID, endpointID string, itemsPerPage int64) (*EventDelivery, error)
+	ListEndpointEventDeliveries(projectID, endpointID string, query DeliveryQuery) (*EventDelivery, error)
+	ListEventDeliveries(projectID string, query DeliveryQuery) (*EventDelivery, error)
+	ListDeliveriesForEvent(projectID, eventID string) ([]EventDeliveryContent, error)
+	WaitForDeliverySuccess(ctx context.Context, projectID, endpointID, eventID string, timeout time.Duration) error
+	RotateEndpointSecret(projectID, endpointID, newSecret string, expiration time.Duration) (*EndpointResponse, error)
+	RotateAndTestSecret(projectID, endpointID string) error
+	SendTestEvent(projectID, endpointID string) (*EventDelivery, error)
+	GetEventDelivery(projectID, deliveryID string) (*EventDeliveryResponse, error)
+	GetDeliveryStatuses(ctx context.Context, projectID string, ids []string) (map[string]DeliveryStatus, error)
+	GetDeliveryAttempts(projectID, deliveryID string) ([]DeliveryAttempt, error)
+	CountEventDeliveries(projectID string, query DeliveryQuery) (int64, error)
+	ExportEventDeliveries(ctx context.Context, projectID string, query DeliveryQuery, w io.Writer) (int, error)
+	RetryEventDelivery(projectID, deliveryID string) error
+	RetryEventDeliveries(ctx context.Context, projectID string, deliveryIDs []string) []BatchResult
+	ListRetryableDeliveries(projectID string, within time.Duration) ([]EventDeliveryContent, error)
+	GetEvent(projectID, eventID string) (*EventResponse, error)
+	SearchEvents(projectID string, query SearchQuery) (*EventList, error)
+	ReplayEvents(ctx context.Context, projectID string, query ReplayQuery) (*ReplayProgress, error)
+	ListSources(projectID string, query SourceQuery) (*SourceList, error)
+	IngestEvent(ctx context.Context, maskID string, body []byte, header http.Header) error
+	CreateSubscription(projectID string, params CreateSubscriptionParams) (*Subscription, error)
+	GetSubscription(projectID, subscriptionID string) (*Subscription, error)
+	AddEventTypeToSubscription(projectID, subscriptionID, eventType string) (*Subscription, error)
+	RemoveEventTypeFromSubscription(projectID, subscriptionID, eventType string) (*Subscription, error)
+	ListSubscriptions(projectID string, query SubscriptionQuery) (*SubscriptionList, error)
+	DeleteSubscription(projectID, subscriptionID string) error
+	GetSubscriptionStats(projectID, subscriptionID string, window time.Duration) (*SubscriptionStats, error)
+	GetProject(projectID string) (*Project, error)
+	GetProjectStats(projectID string, window time.Duration) (*ProjectStats, error)
+	GetServerVersion(ctx context.Context) (string, error)
+	GetInstanceConfig(ctx context.Context) (*InstanceConfig, error)
+	Ping(ctx context.Context) error
+	Warmup(ctx context.Context) error
+	RequireServerVersion(ctx context.Context, minVersion string) error
+	SetAPIKey(key string)
+	ForProject(projectID string) *webhookService
+	Clone(opts ...Option) (*webhookService, error)
 }
 
 type webhookService struct {
@@ -28,19 +93,210 @@ type webhookService struct {
 }
 
 type webhookData struct {
-	url string
+	url     string
+	logger  *slog.Logger
+	metrics Metrics
+	clock   Clock
+	dryRun  bool
+	// strictDecoding rejects response fields the target type doesn't model.
+	strictDecoding bool
+	canonicalJSON  bool
+	useNumber      bool
+	// responseDebugLog logs CreateEvent response bodies at debug level.
+	responseDebugLog bool
+	// successCodes overrides the default 2xx success range per HTTP method.
+	successCodes    map[string][]int
+	redirectPolicy  RedirectPolicy
+	maxPayloadBytes int
+	streamThreshold int
+	// eventHeaders are sent with every CreateEvent request.
+	eventHeaders http.Header
+	accept       string
+	contentType  string
+	// userAgentSuffix is appended to the SDK's User-Agent.
+	userAgentSuffix string
+	maxRetries      int
+	backoff         Backoff
+	retryDecider    func(resp *http.Response, err error, attempt int) bool
+	// timeout replaces the built-in per-method timeouts when set, and
+	// opTimeouts overrides it for single methods.
+	timeout    time.Duration
+	opTimeouts map[string]time.Duration
+	// maxLogBody caps logged bodies; zero disables the cap.
+	maxLogBody int
+	// cache, when set, makes GET requests conditional.
+	cache Cache
+	// inFlight, when set, holds a slot per request in progress.
+	inFlight chan struct{}
+	// eventAttempt is called around every attempt to create an event.
+	eventAttempt func(EventAttempt)
+	// transport is used by every request; nil means http.DefaultTransport.
+	// sharedTransport marks one inherited through Clone, which options must
+	// not modify in place.
+	transport       http.RoundTripper
+	sharedTransport bool
+	endpointNameTTL time.Duration
+
+	defaultProject string
+	// creds is shared with clients derived through ForProject.
+	creds         *credentials
+	serverVersion *serverVersion
+	limits        *instanceConfigs
+	endpointNames *endpointNames
+	failover      *failover
+}
+
+type credentials struct {
+	mu  sync.RWMutex
 	key string
+	pat string
 }
 
+func (c *credentials) get() (key, pat string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.key, c.pat
+}
+
+// credential selects the token a request is authorised with.
+type credential int
+
+const (
+	// projectCredential is the project API key, used by every method that
+	// operates inside a project (endpoints, events, deliveries, sources).
+	projectCredential credential = iota
+	// personalCredential is a personal access token, preferred by
+	// account-level methods such as GetProject.
+	personalCredential
+)
+
 var _ WebhookInterface = &webhookService{}
 
-func NewWebhook(url, key, defaultProject string) *webhookService {
-	return &webhookService{
-		&webhookData{
-			url: url,
-			key: key,
-		},
+// NewWebhook returns a client for the Convoy instance at baseURL, which must
+// be an absolute http or https URL; otherwise the error wraps ErrInvalidURL.
+// The client is safe for concurrent use by multiple goroutines, including
+// calls to SetAPIKey while requests are in flight. Options must not be
+// applied after creation.
+func NewWebhook(baseURL, key, defaultProject string, opts ...Option) (*webhookService, error) {
+	if err := validateURL(baseURL); err != nil {
+		return nil, err
+	}
+
+	we := &webhookData{
+		url:             strings.TrimSuffix(baseURL, "/"),
+		defaultProject:  defaultProject,
+		creds:           &credentials{key: key},
+		serverVersion:   &serverVersion{},
+		limits:          &instanceConfigs{},
+		endpointNames:   &endpointNames{},
+		endpointNameTTL: DefaultEndpointNameTTL,
+		logger:          slog.Default(),
+		metrics:         nopMetrics{},
+		clock:           realClock{},
+		backoff:         ExponentialBackoff{Base: 500 * time.Millisecond, Max: 10 * time.Second},
+		maxPayloadBytes: DefaultMaxPayloadBytes,
+		streamThreshold: DefaultStreamThreshold,
+		maxLogBody:      DefaultMaxLogBodyBytes,
+		accept:          "application/json",
+		contentType:     "application/json",
+	}
+	for _, opt := range opts {
+		opt(we)
+	}
+	if we.failover != nil {
+		if err := we.failover.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &webhookService{we}, nil
+}
+
+// validateURL checks that raw is an absolute http or https URL, returning
+// an error wrapping ErrInvalidURL otherwise.
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be http or https, got %q", ErrInvalidURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: missing host in %q", ErrInvalidURL, raw)
+	}
+	return nil
+}
+
+// MustNewWebhook is like NewWebhook but panics if baseURL is invalid.
+func MustNewWebhook(baseURL, key, defaultProject string, opts ...Option) *webhookService {
+	service, err := NewWebhook(baseURL, key, defaultProject, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// SetAPIKey replaces the API key used for subsequent requests, including
+// those of clients derived through ForProject. Requests already in flight
+// keep the key they were sent with.
+func (we *webhookData) SetAPIKey(key string) {
+	we.creds.mu.Lock()
+	defer we.creds.mu.Unlock()
+	we.creds.key = key
+}
+
+// token returns the token for the wanted credential, falling back to the
+// other one when it isn't configured.
+func (we *webhookData) token(want credential) string {
+	key, pat := we.creds.get()
+	if want == personalCredential && pat != "" || key == "" {
+		return pat
 	}
+	return key
+}
+
+// ForProject returns a client sharing this client's configuration and
+// credentials whose default project is projectID.
+//
+// Every method taking a projectID resolves it in this order: a non-empty
+// projectID argument, then the project of a ForProject client, then the
+// defaultProject given to NewWebhook.
+func (we *webhookData) ForProject(projectID string) *webhookService {
+	scoped := *we
+	scoped.defaultProject = projectID
+	return &webhookService{&scoped}
+}
+
+// Clone returns a client with this client's configuration, changed by opts.
+// The clone shares the transport and its connection pool, the credentials
+// (so SetAPIKey affects both, unless opts set a key or token), the response
+// cache, the concurrency limit of WithMaxConcurrency (unless opts set their
+// own) and the cached server version, instance configs and endpoint names.
+// Options that tune the transport apply to a copy of it. Like NewWebhook,
+// Clone fails if opts configure an invalid failover URL.
+func (we *webhookData) Clone(opts ...Option) (*webhookService, error) {
+	clone := *we
+	clone.successCodes = maps.Clone(we.successCodes)
+	clone.opTimeouts = maps.Clone(we.opTimeouts)
+	clone.sharedTransport = we.transport != nil
+	for _, opt := range opts {
+		opt(&clone)
+	}
+	if clone.failover != we.failover {
+		if err := clone.failover.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &webhookService{&clone}, nil
+}
+
+// projectPath returns the API path of the resolved project followed by elems.
+func (we *webhookData) projectPath(projectID string, elems ...any) string {
+	if projectID == "" {
+		projectID = we.defaultProject
+	}
+	return fmt.Sprint(append([]any{"/api/v1/projects/", projectID}, elems...)...)
 }
 
 type EndpointToggleStatus struct {
@@ -68,9 +324,17 @@ type UpsertEndpointParams struct {
 	URL                string `json:"url"`
 	AdvancedSignatures bool   `json:"advanced_signatures"`
 	AppID              string `json:"appID"` // deprecated but required
-	// Authentication
-	Description       string `json:"description"`
-	HttpTimeout       int64  `json:"http_timeout"`
+	// Authentication adds a static header to every delivery.
+	Authentication *EndpointAuthentication `json:"authentication,omitempty"`
+	// Description may carry metadata, see EncodeDescription.
+	Description string `json:"description"`
+	HttpTimeout int64  `json:"http_timeout"`
+	// IsDisabled creates or updates the endpoint as inactive
+	// (EndpointStatusInactive), the status Convoy itself gives endpoints
+	// it disables after repeated failures when the project's
+	// DisableEndpoint is on. Either way ActivateEndpoint brings it back;
+	// TogglePause only switches between active and paused, and leaves an
+	// inactive endpoint inactive.
 	IsDisabled        bool   `json:"is_disabled"`
 	OwnerID           string `json:"owner_id"`
 	RateLimit         int64  `json:"rate_limit"`
@@ -78,33 +342,47 @@ type UpsertEndpointParams struct {
 	Secret            string `json:"secret"`
 	SlackWebhookURL   string `json:"slack_webhook_url"`
 	SupportEmail      string `json:"support_email"`
+
+	// IdempotencyKey makes CreateEndpoint retriable when retries are
+	// enabled: every attempt carries the same Idempotency-Key header.
+	// Convoy itself does not deduplicate endpoint creation, so a retry
+	// after a request that reached the server can still create a second
+	// endpoint unless a gateway in front of Convoy honours the key.
+	IdempotencyKey string `json:"-"`
 }
 
 type Endpoint struct {
 	Message string       `json:"message"`
 	Status  bool         `json:"status"`
 	Data    EndpointData `json:"data"`
+	// Subscriptions routing events to the endpoint, filled only when
+	// GetEndpoint is called with IncludeSubscriptions.
+	Subscriptions []SubscriptionData `json:"-"`
 }
 
 type EndpointData struct {
-	// Authentication
-	// Secrets
-	SlackWebhookURL   string     `json:"slack_webhook_url"`
-	Status            string     `json:"status"`
-	SupportEmail      string     `json:"support_email"`
-	UID               string     `json:"uid"`
-	UpdatedAt         time.Time  `json:"updated_at"`
-	URL               string     `json:"url"`
-	CreatedAt         time.Time  `json:"created_at"`
-	DeletedAt         *time.Time `json:"deleted_at"`
-	Description       string     `json:"description"`
-	Events            int64      `json:"events"`
-	HttpTimeout       int64      `json:"http_timeout"`
-	Name              string     `json:"name"`
-	OwnerID           string     `json:"owner_id"`
-	ProjectID         string     `json:"project_id"`
-	RateLimit         int64      `json:"rate_limit"`
-	RateLimitDuration int64      `json:"rate_limit_duration"`
+	// AppID is the deprecated application ID, reported by servers that
+	// still track it.
+	AppID              string                  `json:"app_id"`
+	Authentication     *EndpointAuthentication `json:"authentication"`
+	Secrets            []EndpointSecret        `json:"secrets"`
+	AdvancedSignatures bool                    `json:"advanced_signatures"`
+	SlackWebhookURL    string                  `json:"slack_webhook_url"`
+	Status             string                  `json:"status"`
+	SupportEmail       string                  `json:"support_email"`
+	UID                string                  `json:"uid"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+	URL                string                  `json:"url"`
+	CreatedAt          time.Time               `json:"created_at"`
+	DeletedAt          *time.Time              `json:"deleted_at"`
+	Description        string                  `json:"description"`
+	Events             int64                   `json:"events"`
+	HttpTimeout        int64                   `json:"http_timeout"`
+	Name               string                  `json:"name"`
+	OwnerID            string                  `json:"owner_id"`
+	ProjectID          string                  `json:"project_id"`
+	RateLimit          int64                   `json:"rate_limit"`
+	RateLimitDuration  int64                   `json:"rate_limit_duration"`
 }
 
 type Webhook struct {
@@ -117,99 +395,126 @@ type WebhookData struct {
 	EventType      string      `json:"event_type"`
 	EndpointID     string      `json:"endpoint_id"`
 	IdempotencyKey string      `json:"idempotency_key"`
+	// CustomHeaders are forwarded by Convoy to the endpoint with the event.
+	CustomHeaders map[string]string `json:"custom_headers,omitempty"`
+	// DeliverAt asks for delivery no earlier than the given future time.
+	// No Convoy release supports scheduled events, so rather than deliver
+	// early, creating an event with DeliverAt set fails with
+	// ErrUnsupportedByServer.
+	DeliverAt time.Time `json:"-"`
+}
+
+// EventAttempt describes one attempt to create an event, see
+// WithEventAttemptCallback.
+type EventAttempt struct {
+	// Op is the client method, such as "CreateEvent" or "Publish".
+	Op string
+	// Attempt counts from 1; it exceeds 1 only when retries are enabled.
+	Attempt int
+	// Completed is false for the call made before the request is sent and
+	// true for the one made after it.
+	Completed bool
+	// StatusCode is Convoy's response status, or 0 if there was none.
+	StatusCode int
+	Latency    time.Duration
+	// Err is the transport error of the attempt, if any.
+	Err error
+}
+
+type EventResponse struct {
+	Message string    `json:"message"`
+	Status  bool      `json:"status"`
+	Data    EventData `json:"data"`
+}
+
+type EventData struct {
+	UID            string   `json:"uid"`
+	EventType      string   `json:"event_type"`
+	ProjectID      string   `json:"project_id"`
+	Endpoints      []string `json:"endpoints"`
+	IdempotencyKey string   `json:"idempotency_key"`
+	// Data is the event payload. It is not set on the response of
+	// CreateEvent.
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
 }
 
 type EventDelivery struct {
-	Message string `json:"message"`
-	Status  bool   `json:"status"`
-	Data    struct {
-		Content []EventDeliveryContent `json:"content"`
-	} `json:"data"`
+	Message string                     `json:"message"`
+	Status  bool                       `json:"status"`
+	Data    Page[EventDeliveryContent] `json:"data"`
+}
+
+type Pagination struct {
+	PerPage        int64  `json:"per_page"`
+	HasNextPage    bool   `json:"has_next_page"`
+	HasPrevPage    bool   `json:"has_prev_page"`
+	NextPageCursor string `json:"next_page_cursor"`
+	PrevPageCursor string `json:"prev_page_cursor"`
+}
+
+func setPageParams(query url.Values, perPage int64, next, prev string) {
+	if perPage > 0 {
+		query.Set("perPage", strconv.FormatInt(perPage, 10))
+	}
+	switch {
+	case next != "":
+		query.Set("next_page_cursor", next)
+		query.Set("direction", "next")
+	case prev != "":
+		query.Set("prev_page_cursor", prev)
+		query.Set("direction", "prev")
+	}
 }
 
 type EventDeliveryContent struct {
-	CreatedAt time.Time `json:"created_at"`
-	// EventID       string    `json:"event_id"`
-	Status        string `json:"status"`
+	UID        string         `json:"uid"`
+	CreatedAt  time.Time      `json:"created_at"`
+	EventID    string         `json:"event_id"`
+	EndpointID string         `json:"endpoint_id"`
+	Status     DeliveryStatus `json:"status"`
+	// Description is Convoy's reason for the status, such as the error of
+	// the last failed attempt. Per-attempt detail, including the receiver's
+	// status code, is available from GetDeliveryAttempts.
+	Description   string `json:"description"`
 	EventMetadata struct {
 		EventType string `json:"event_type"`
 	} `json:"event_metadata"`
 	Metadata struct {
-		NumTrials  int64 `json:"num_trials"`
-		RetryLimit int64 `json:"retry_limit"`
+		// Data is the event payload as sent, for decoding into the
+		// caller's own types.
+		Data       json.RawMessage `json:"data"`
+		NumTrials  int64           `json:"num_trials"`
+		RetryLimit int64           `json:"retry_limit"`
 	} `json:"metadata"`
 }
 
 func (we *webhookData) GetEndpointEventDeliveries(projectID, endpointID string, itemsPerPage int64) (*EventDelivery, error) {
-	req, err := http.NewRequest(
-		http.MethodGet,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/eventdeliveries"),
-		nil,
-	)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
-	query := url.Values{
-		"endpointId": []string{endpointID},
-		"perPage":    []string{strconv.FormatInt(itemsPerPage, 10)},
-	}
-	req.URL.RawQuery = query.Encode()
-
-	client := &http.Client{
-		Timeout: 2 * time.Second,
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
-		}
-	}(resp.Body)
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code %d invalid", resp.StatusCode)
-	}
-
-	var delivery EventDelivery
-	if err := json.NewDecoder(resp.Body).Decode(&delivery); err != nil {
-		return nil, err
-	}
-
-	return &delivery, nil
+	return we.ListEndpointEventDeliveries(projectID, endpointID, DeliveryQuery{PerPage: itemsPerPage})
 }
 
 func (we *webhookData) TogglePause(projectID, endpointID string) (string, error) {
-	req, err := http.NewRequest(
-		http.MethodPut,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/endpoints/", endpointID, "/pause"),
-		nil,
-	)
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
+	return we.togglePause(context.Background(), projectID, endpointID)
+}
 
-	client := &http.Client{
-		Timeout: 2 * time.Second,
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
-		}
-	}(resp.Body)
-	if resp.StatusCode >= 300 {
-		return "", fmt.Errorf("response code %d invalid", resp.StatusCode)
-	}
+// TogglePauseContext is TogglePause with a context. Toggling is not
+// idempotent, so it is never retried; prefer SetEndpointPaused when the
+// desired state is known.
+func (we *webhookData) TogglePauseContext(ctx context.Context, projectID, endpointID string) (string, error) {
+	return we.togglePause(ctx, projectID, endpointID)
+}
 
+func (we *webhookData) togglePause(ctx context.Context, projectID, endpointID string) (string, error) {
 	var endpoint EndpointToggleStatus
-	if err := json.NewDecoder(resp.Body).Decode(&endpoint); err != nil {
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "TogglePause",
+		method:  http.MethodPut,
+		path:    we.projectPath(projectID, "/endpoints/", endpointID, "/pause"),
+		timeout: 2 * time.Second,
+	}, &endpoint)
+	if err != nil {
 		return "", err
 	}
 
@@ -217,41 +522,29 @@ func (we *webhookData) TogglePause(projectID, endpointID string) (string, error)
 }
 
 func (we *webhookData) CreateEndpoint(projectID string, params UpsertEndpointParams) (*CreateEndpointResponse, error) {
-	buff := new(bytes.Buffer)
-	err := json.NewEncoder(buff).Encode(params)
-	if err != nil {
-		return nil, err
-	}
+	return we.createEndpoint(context.Background(), projectID, params)
+}
 
-	req, err := http.NewRequest(
-		http.MethodPost,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/endpoints"),
-		buff,
-	)
-	if err != nil {
+func (we *webhookData) createEndpoint(ctx context.Context, projectID string, params UpsertEndpointParams) (*CreateEndpointResponse, error) {
+	if err := params.Authentication.validate(); err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: 2 * time.Second,
-	}
-	resp, err := client.Do(req)
+	body, err := json.Marshal(params)
 	if err != nil {
 		return nil, err
 	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
-		}
-	}(resp.Body)
-	if resp.StatusCode > http.StatusBadRequest {
-		return nil, fmt.Errorf("response code %d invalid", resp.StatusCode)
-	}
 
 	var response CreateEndpointResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	err = we.do(request{
+		ctx:            ctx,
+		op:             "CreateEndpoint",
+		method:         http.MethodPost,
+		path:           we.projectPath(projectID, "/endpoints"),
+		body:           body,
+		timeout:        2 * time.Second,
+		idempotencyKey: params.IdempotencyKey,
+	}, &response)
+	if err != nil {
 		return nil, err
 	}
 
@@ -259,159 +552,250 @@ func (we *webhookData) CreateEndpoint(projectID string, params UpsertEndpointPar
 }
 
 func (we *webhookData) UpdateEndpoint(projectID, endpointID string, params UpsertEndpointParams) (*EndpointResponse, error) {
-	buff := new(bytes.Buffer)
-	err := json.NewEncoder(buff).Encode(params)
-	if err != nil {
-		return nil, err
-	}
+	return we.updateEndpoint(projectID, endpointID, endpointUpdate{
+		UpsertEndpointParams: params,
+		IsDisabled:           &params.IsDisabled,
+	})
+}
 
-	req, err := http.NewRequest(
-		http.MethodPut,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/endpoints/", endpointID),
-		buff,
-	)
-	if err != nil {
+func (we *webhookData) updateEndpoint(projectID, endpointID string, update endpointUpdate) (*EndpointResponse, error) {
+	if err := update.Authentication.validate(); err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: 2 * time.Second,
-	}
-	resp, err := client.Do(req)
+	body, err := json.Marshal(update)
 	if err != nil {
 		return nil, err
 	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
-		}
-	}(resp.Body)
-	if resp.StatusCode > http.StatusBadRequest {
-		return nil, fmt.Errorf("response code %d invalid", resp.StatusCode)
-	}
 
 	var response EndpointResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	err = we.do(request{
+		op:      "UpdateEndpoint",
+		method:  http.MethodPut,
+		path:    we.projectPath(projectID, "/endpoints/", endpointID),
+		body:    body,
+		timeout: 2 * time.Second,
+	}, &response)
+	if err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (we *webhookData) DeleteEndpoint(projectID, endpointID string) (*EndpointResponse, error) {
-	req, err := http.NewRequest(
-		http.MethodDelete,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/endpoints/", endpointID),
-		nil,
-	)
+// TransferEndpointOwner re-parents an endpoint to newOwnerID. Convoy
+// replaces the whole endpoint on update, so the endpoint is fetched first and
+// its other settings are sent back unchanged.
+func (we *webhookData) TransferEndpointOwner(projectID, endpointID, newOwnerID string) (*EndpointResponse, error) {
+	endpoint, err := we.GetEndpoint(projectID, endpointID)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
+	update := currentParams(endpoint.Data)
+	update.OwnerID = newOwnerID
+	return we.updateEndpoint(projectID, endpointID, update)
+}
 
-	client := &http.Client{
-		Timeout: 2 * time.Second,
+// UpdateEndpointURL points the endpoint at newURL, which must be an absolute
+// http or https URL. Convoy replaces the whole endpoint on update, so the
+// endpoint is fetched first and its other settings are sent back unchanged.
+func (we *webhookData) UpdateEndpointURL(projectID, endpointID, newURL string) (*EndpointResponse, error) {
+	if err := validateURL(newURL); err != nil {
+		return nil, err
 	}
-	resp, err := client.Do(req)
+
+	endpoint, err := we.GetEndpoint(projectID, endpointID)
 	if err != nil {
 		return nil, err
 	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
-		}
-	}(resp.Body)
-	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("response code %d invalid", resp.StatusCode)
-	}
+	update := currentParams(endpoint.Data)
+	update.URL = newURL
+	return we.updateEndpoint(projectID, endpointID, update)
+}
 
+// endpointUpdate is the body of an endpoint update. Convoy sets the status
+// from is_disabled whenever it is sent, so an update carrying
+// is_disabled:false reactivates a paused or inactive endpoint; IsDisabled
+// shadows the field of UpsertEndpointParams so that a nil value keeps the
+// status.
+type endpointUpdate struct {
+	UpsertEndpointParams
+	IsDisabled *bool `json:"is_disabled,omitempty"`
+}
+
+// currentParams returns the settings of an endpoint as an update that sends
+// them back unchanged. The status is kept by leaving is_disabled out, and the
+// secret by leaving it empty, since Convoy only replaces the secret when one
+// is sent.
+func currentParams(current EndpointData) endpointUpdate {
+	return endpointUpdate{UpsertEndpointParams: UpsertEndpointParams{
+		Name:               current.Name,
+		URL:                current.URL,
+		AdvancedSignatures: current.AdvancedSignatures,
+		AppID:              current.AppID,
+		Authentication:     current.Authentication,
+		Description:        current.Description,
+		HttpTimeout:        current.HttpTimeout,
+		OwnerID:            current.OwnerID,
+		RateLimit:          current.RateLimit,
+		RateLimitDuration:  current.RateLimitDuration,
+		SlackWebhookURL:    current.SlackWebhookURL,
+		SupportEmail:       current.SupportEmail,
+	}}
+}
+
+func (we *webhookData) DeleteEndpoint(projectID, endpointID string) (*EndpointResponse, error) {
+	return we.deleteEndpoint(context.Background(), projectID, endpointID)
+}
+
+func (we *webhookData) deleteEndpoint(ctx context.Context, projectID, endpointID string) (*EndpointResponse, error) {
 	var endpoint EndpointResponse
-	if err := json.NewDecoder(resp.Body).Decode(&endpoint); err != nil {
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "DeleteEndpoint",
+		method:  http.MethodDelete,
+		path:    we.projectPath(projectID, "/endpoints/", endpointID),
+		timeout: 2 * time.Second,
+	}, &endpoint)
+	if err != nil {
 		return nil, err
 	}
 
 	return &endpoint, nil
 }
 
-func (we *webhookData) GetEndpoint(projectID, endpointID string) (*Endpoint, error) {
-	req, err := http.NewRequest(
-		http.MethodGet,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/endpoints/", endpointID),
-		nil,
-	)
-	if err != nil {
-		return nil, err
+// GetEndpointOption configures GetEndpoint.
+type GetEndpointOption func(*getEndpointOptions)
+
+type getEndpointOptions struct {
+	includeSubscriptions bool
+}
+
+// IncludeSubscriptions makes GetEndpoint also fetch the subscriptions of the
+// endpoint into Endpoint.Subscriptions. Convoy has no expansion for this, so
+// it costs one more request per page of subscriptions.
+func IncludeSubscriptions() GetEndpointOption {
+	return func(o *getEndpointOptions) {
+		o.includeSubscriptions = true
 	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
+}
 
-	client := &http.Client{
-		Timeout: 2 * time.Second,
+func (we *webhookData) GetEndpoint(projectID, endpointID string, opts ...GetEndpointOption) (*Endpoint, error) {
+	var options getEndpointOptions
+	for _, opt := range opts {
+		opt(&options)
 	}
-	resp, err := client.Do(req)
+
+	ctx := context.Background()
+	endpoint, err := we.getEndpoint(ctx, projectID, endpointID)
 	if err != nil {
 		return nil, err
 	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
+	if options.includeSubscriptions {
+		endpoint.Subscriptions, err = we.listAllSubscriptions(ctx, projectID, SubscriptionQuery{EndpointID: endpointID})
+		if err != nil {
+			return nil, err
 		}
-	}(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("response code %d invalid", resp.StatusCode)
 	}
 
+	return endpoint, nil
+}
+
+func (we *webhookData) getEndpoint(ctx context.Context, projectID, endpointID string) (*Endpoint, error) {
 	var endpoint Endpoint
-	if err := json.NewDecoder(resp.Body).Decode(&endpoint); err != nil {
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "GetEndpoint",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/endpoints/", endpointID),
+		timeout: 2 * time.Second,
+	}, &endpoint)
+	if err != nil {
 		return nil, err
 	}
 
 	return &endpoint, nil
 }
 
+func (we *webhookData) GetEvent(projectID, eventID string) (*EventResponse, error) {
+	return we.getEvent(context.Background(), projectID, eventID)
+}
+
+func (we *webhookData) getEvent(ctx context.Context, projectID, eventID string) (*EventResponse, error) {
+	var event EventResponse
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "GetEvent",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/events/", eventID),
+		timeout: 2 * time.Second,
+	}, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
 func (we *webhookData) CreateEvent(projectID string, webhookData *Webhook) error {
-	if webhookData == nil {
-		return errors.New("webhook data undefined")
+	_, err := we.createEvent(context.Background(), projectID, webhookData)
+	return err
+}
+
+// checkDeliverAt validates WebhookData.DeliverAt, which no server supports.
+func (we *webhookData) checkDeliverAt(at time.Time) error {
+	if at.IsZero() {
+		return nil
 	}
+	if !at.After(we.clock.Now()) {
+		return fmt.Errorf("convoy: DeliverAt %s is not in the future", at.Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w: scheduled delivery", ErrUnsupportedByServer)
+}
 
-	jsonBytes, err := json.Marshal(webhookData.Data)
-	if err != nil {
-		return err
+func (we *webhookData) createEvent(ctx context.Context, projectID string, webhookData *Webhook) (*EventResponse, error) {
+	if webhookData == nil {
+		return nil, errors.New("webhook data undefined")
+	}
+	if err := we.checkDeliverAt(webhookData.Data.DeliverAt); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest(
-		http.MethodPost,
-		fmt.Sprint(we.url, "/api/v1/projects/", projectID, "/events"),
-		bytes.NewBuffer(jsonBytes),
-	)
+	jsonBytes, err := we.marshalEvent(webhookData.Data)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if webhookData.Headers != nil {
-		req.Header = map[string][]string(webhookData.Headers)
+	if err := we.checkPayloadSize(len(jsonBytes)); err != nil {
+		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprint("Bearer ", we.key))
-	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	var body []byte
+	err = we.do(request{
+		ctx:    ctx,
+		op:     "CreateEvent",
+		method: http.MethodPost,
+		path:   we.projectPath(projectID, "/events"),
+		header: mergeHeaders(we.eventHeaders, webhookData.Headers),
+		body:   jsonBytes,
+		// Convoy deduplicates events on idempotency_key, so keyed events
+		// are safe to retry.
+		idempotencyKey: webhookData.Data.IdempotencyKey,
+		onAttempt:      we.eventAttempt,
+	}, &body)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			slog.Error("error closing response body", "err", err)
-		}
-	}(resp.Body)
-
-	if body, err := io.ReadAll(resp.Body); err == nil {
-		slog.Info(string(body)) // TODO
+	if we.responseDebugLog {
+		we.logger.Debug("created event", "op", "CreateEvent", "body", we.logBody(body))
 	}
 
-	if resp.StatusCode >= 400 {
-		return errors.New("error status code " + strconv.FormatInt(int64(resp.StatusCode), 10))
+	var event EventResponse
+	if len(body) == 0 {
+		event.setSucceeded()
+		return &event, nil
+	}
+	if err := we.unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: decoding created event: %w", ErrMalformedResponse, err)
 	}
 
-	return nil
+	return &event, nil
 }
@@ -0,0 +1,224 @@
+package convoy_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	convoy "github.com/formflake/convoy-go"
+	"github.com/formflake/convoy-go/convoytest"
+)
+
+func newFake(t *testing.T, opts ...convoy.Option) (*convoytest.Server, convoy.WebhookInterface) {
+	t.Helper()
+	srv := convoytest.Start()
+	t.Cleanup(srv.Close)
+	client, err := convoy.NewWebhook(srv.URL, "key", "project", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+var billingEndpoint = convoy.UpsertEndpointParams{
+	Name:              "billing",
+	URL:               "https://billing.example.com/hook",
+	Description:       "invoices",
+	HttpTimeout:       10,
+	OwnerID:           "owner-1",
+	RateLimit:         100,
+	RateLimitDuration: 60,
+	Secret:            "whsec_1",
+	SupportEmail:      "ops@example.com",
+	Authentication:    convoy.NewAPIKeyAuthentication("X-Api-Key", "k1"),
+}
+
+func TestUpdateEndpointURLPreservesOtherFields(t *testing.T) {
+	for _, status := range []string{convoy.EndpointStatusActive, convoy.EndpointStatusPaused, convoy.EndpointStatusInactive} {
+		t.Run(status, func(t *testing.T) {
+			srv, client := newFake(t)
+			params := billingEndpoint
+			params.IsDisabled = status == convoy.EndpointStatusInactive
+			created, err := client.CreateEndpoint("", params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := created.Data.Uid
+			if status == convoy.EndpointStatusPaused {
+				if _, err := client.PauseEndpoint("", id); err != nil {
+					t.Fatal(err)
+				}
+			}
+			before := srv.Endpoints()[0]
+
+			if _, err := client.UpdateEndpointURL("", id, "https://billing.example.com/v2"); err != nil {
+				t.Fatal(err)
+			}
+			after := srv.Endpoints()[0]
+			if after.URL != "https://billing.example.com/v2" {
+				t.Errorf("URL = %q, want the new URL", after.URL)
+			}
+			after.URL, after.UpdatedAt = before.URL, before.UpdatedAt
+			if !reflect.DeepEqual(after, before) || after.Status != status {
+				t.Errorf("other fields changed:\nbefore %+v\nafter  %+v", before, after)
+			}
+		})
+	}
+}
+
+func TestUpdateEndpointPatchLeavesUnspecifiedFields(t *testing.T) {
+	srv, client := newFake(t)
+	created, err := client.CreateEndpoint("", billingEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := created.Data.Uid
+	if _, err := client.PauseEndpoint("", id); err != nil {
+		t.Fatal(err)
+	}
+	before := srv.Endpoints()[0]
+
+	_, err = client.UpdateEndpointPatch("", id, map[string]any{
+		"support_email": "billing@example.com",
+		"description":   nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := srv.Endpoints()[0]
+	if after.SupportEmail != "billing@example.com" || after.Description != "" {
+		t.Errorf("support email %q, description %q; want the patched values", after.SupportEmail, after.Description)
+	}
+	if after.Status != convoy.EndpointStatusPaused {
+		t.Errorf("status = %q, want it to stay %q", after.Status, convoy.EndpointStatusPaused)
+	}
+	after.SupportEmail, after.Description, after.UpdatedAt = before.SupportEmail, before.Description, before.UpdatedAt
+	if !reflect.DeepEqual(after, before) {
+		t.Errorf("unspecified fields changed:\nbefore %+v\nafter  %+v", before, after)
+	}
+
+	if _, err := client.UpdateEndpointPatch("", id, map[string]any{"is_disabled": false}); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Endpoints()[0].Status; got != convoy.EndpointStatusActive {
+		t.Errorf("status after patching is_disabled = %q, want %q", got, convoy.EndpointStatusActive)
+	}
+}
+
+func TestUpdateEndpointPatchRejectsUnknownFields(t *testing.T) {
+	srv, client := newFake(t)
+	created, err := client.CreateEndpoint("", billingEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.UpdateEndpointPatch("", created.Data.Uid, map[string]any{"supportemail": "x@example.com"})
+	if err == nil {
+		t.Fatal("patch with an unknown field succeeded")
+	}
+	if got := srv.Endpoints()[0].SupportEmail; got != billingEndpoint.SupportEmail {
+		t.Errorf("support email = %q, want it unchanged", got)
+	}
+}
+
+func TestTransferEndpointOwnerBody(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `{"status":true,"data":{"uid":"ep-1","name":"billing",
+				"url":"https://billing.example.com/hook","owner_id":"owner-1","status":"paused",
+				"support_email":"ops@example.com","rate_limit":100,"rate_limit_duration":60}}`)
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			io.WriteString(w, `{"status":true,"message":"Endpoint updated successfully"}`)
+		}
+	}))
+	defer srv.Close()
+	client, err := convoy.NewWebhook(srv.URL, "key", "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.TransferEndpointOwner("", "ep-1", "owner-2"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"owner_id":      "owner-2",
+		"name":          "billing",
+		"url":           "https://billing.example.com/hook",
+		"support_email": "ops@example.com",
+		"rate_limit":    100.0,
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("body[%q] = %v, want %v", key, body[key], value)
+		}
+	}
+	if _, ok := body["is_disabled"]; ok {
+		t.Errorf("body carries is_disabled, which would change the status: %v", body)
+	}
+}
+
+func TestTransferEndpointOwner(t *testing.T) {
+	srv, client := newFake(t)
+	created, err := client.CreateEndpoint("", billingEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := srv.Endpoints()[0]
+
+	if _, err := client.TransferEndpointOwner("", created.Data.Uid, "owner-2"); err != nil {
+		t.Fatal(err)
+	}
+	after := srv.Endpoints()[0]
+	if after.OwnerID != "owner-2" {
+		t.Errorf("owner = %q, want owner-2", after.OwnerID)
+	}
+	after.OwnerID, after.UpdatedAt = before.OwnerID, before.UpdatedAt
+	if !reflect.DeepEqual(after, before) {
+		t.Errorf("other fields changed:\nbefore %+v\nafter  %+v", before, after)
+	}
+}
+
+func TestPauseEndpointsByOwner(t *testing.T) {
+	srv, client := newFake(t)
+	var ids []string
+	for _, owner := range []string{"owner-1", "owner-1", "owner-2"} {
+		params := billingEndpoint
+		params.OwnerID = owner
+		created, err := client.CreateEndpoint("", params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, created.Data.Uid)
+	}
+
+	results, err := client.PauseEndpointsByOwner(context.Background(), "", "owner-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed := convoy.Failures(results); len(failed) != 0 || len(results) != 2 {
+		t.Fatalf("results = %+v, want two successes", results)
+	}
+	paused := map[string]bool{}
+	for _, result := range results {
+		paused[result.ID] = true
+	}
+	for _, endpoint := range srv.Endpoints() {
+		want := convoy.EndpointStatusActive
+		if paused[endpoint.UID] {
+			want = convoy.EndpointStatusPaused
+		}
+		if endpoint.Status != want || paused[endpoint.UID] != (endpoint.OwnerID == "owner-1") {
+			t.Errorf("endpoint %s of %s is %s", endpoint.UID, endpoint.OwnerID, endpoint.Status)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package convoyfmt renders convoy resources for command-line tools.
+//
+// Output is stable: struct fields keep their declaration order and map keys
+// are sorted, so the same resource always renders identically. Secrets, such
+// as endpoint secrets, API key authentication and verifier passwords, are
+// rendered as "[REDACTED]", using the same keys the client's debug log hides.
+package convoyfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/formflake/convoy-go/internal/sensitive"
+)
+
+type Style int
+
+const (
+	// Compact renders one resource per line.
+	Compact Style = iota
+	// Pretty renders indented, multi-line JSON.
+	Pretty
+)
+
+// Marshal renders v as JSON in the given style.
+func Marshal(v any, style Style) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Fprint(&buf, v, style); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Fprint writes v to w as JSON in the given style, followed by a newline.
+// HTML characters are not escaped.
+func Fprint(w io.Writer, v any, style Style) error {
+	var encoded bytes.Buffer
+	enc := json.NewEncoder(&encoded)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	dec := json.NewDecoder(&encoded)
+	dec.UseNumber()
+	if err := redact(&out, dec); err != nil {
+		return err
+	}
+	if style == Pretty {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, out.Bytes(), "", "  "); err != nil {
+			return err
+		}
+		out = indented
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(w)
+	return err
+}
+
+// FprintAll writes each item of items to w as JSON lines in Compact style, or
+// as a single indented array in Pretty style.
+func FprintAll[T any](w io.Writer, items []T, style Style) error {
+	if style == Pretty {
+		if items == nil {
+			items = []T{}
+		}
+		return Fprint(w, items, Pretty)
+	}
+	for _, item := range items {
+		if err := Fprint(w, item, Compact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const redacted = `"[REDACTED]"`
+
+// redact copies the next JSON value of dec to w, replacing secrets. Field
+// order is kept.
+func redact(w *bytes.Buffer, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		w.WriteByte('{')
+		for i := 0; dec.More(); i++ {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key := tok.(string)
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			writeScalar(w, key)
+			w.WriteByte(':')
+			if !sensitive.Field(key) {
+				if err := redact(w, dec); err != nil {
+					return err
+				}
+				continue
+			}
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			if v := string(value); v == "null" || v == `""` {
+				w.Write(value)
+			} else {
+				w.WriteString(redacted)
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		w.WriteByte('}')
+	case json.Delim('['):
+		w.WriteByte('[')
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := redact(w, dec); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		w.WriteByte(']')
+	default:
+		writeScalar(w, tok)
+	}
+	return nil
+}
+
+func writeScalar(w *bytes.Buffer, v any) {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	w.Truncate(w.Len() - 1)
+}
@@ -0,0 +1,55 @@
+package convoyfmt_test
+
+import (
+	"strings"
+	"testing"
+
+	convoy "github.com/formflake/convoy-go"
+	"github.com/formflake/convoy-go/convoyfmt"
+)
+
+func TestMarshalRedactsSecrets(t *testing.T) {
+	endpoint := convoy.EndpointData{
+		UID:            "ep-1",
+		Name:           "billing <eu>",
+		Secrets:        []convoy.EndpointSecret{{UID: "sec-1", Value: "whsec_0123456789"}},
+		Authentication: convoy.NewAPIKeyAuthentication("X-Api-Key", "k1-secret"),
+		RateLimit:      9007199254740993,
+	}
+	for _, style := range []convoyfmt.Style{convoyfmt.Compact, convoyfmt.Pretty} {
+		out, err := convoyfmt.Marshal(endpoint, style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := string(out)
+		for _, leaked := range []string{"whsec_0123456789", "sec-1", "X-Api-Key", "k1-secret"} {
+			if strings.Contains(s, leaked) {
+				t.Errorf("style %d: output contains %q:\n%s", style, leaked, s)
+			}
+		}
+		for _, kept := range []string{"ep-1", "billing <eu>", "9007199254740993", "[REDACTED]"} {
+			if !strings.Contains(s, kept) {
+				t.Errorf("style %d: output lacks %q:\n%s", style, kept, s)
+			}
+		}
+		if strings.Index(s, `"uid"`) > strings.Index(s, `"name"`) {
+			t.Errorf("style %d: fields out of declaration order:\n%s", style, s)
+		}
+	}
+}
+
+func TestMarshalRedactsLoggedFields(t *testing.T) {
+	out, err := convoyfmt.Marshal(map[string]any{
+		"api_key":       "k1-secret",
+		"Authorization": "Bearer tok-1",
+		"token":         "",
+		"name":          "billing",
+	}, convoyfmt.Compact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Authorization":"[REDACTED]","api_key":"[REDACTED]","name":"billing","token":""}`
+	if string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
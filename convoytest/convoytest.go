@@ -0,0 +1,614 @@
+// Package convoytest provides an in-memory fake Convoy server for testing
+// code that uses the convoy client over real HTTP.
+//
+//	srv := convoytest.Start()
+//	defer srv.Close()
+//	client, _ := convoy.NewWebhook(srv.URL, "key", "project")
+//
+// It implements the core routes: endpoint and subscription CRUD, pause and
+// activate, event creation, fan-out and retrieval, and event deliveries.
+// Updating an endpoint replaces all its settings, as Convoy does. Every event
+// is delivered immediately and successfully to its endpoint, or, when none is
+// given, to every endpoint of the project (or of the owner, for fan-out)
+// whose subscriptions match its type. Lists are returned as a single page.
+// The API key is not checked.
+package convoytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	convoy "github.com/formflake/convoy-go"
+)
+
+// Version is reported as the server version.
+const Version = convoy.MinServerVersion
+
+// Server is a fake Convoy server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL to pass to convoy.NewWebhook.
+	URL string
+
+	srv *httptest.Server
+
+	mu         sync.Mutex
+	seq        int
+	endpoints  map[string]*convoy.EndpointData
+	events     map[string]*convoy.EventData
+	deliveries map[string]*convoy.EventDeliveryContent
+	// subscriptions are few, so they are kept in creation order.
+	subscriptions []*convoy.SubscriptionData
+	// order records IDs in creation order, for stable listings.
+	order []string
+}
+
+// Start starts a Server. Close it when done.
+func Start() *Server {
+	s := &Server{
+		endpoints:  make(map[string]*convoy.EndpointData),
+		events:     make(map[string]*convoy.EventData),
+		deliveries: make(map[string]*convoy.EventDeliveryContent),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, "Convoy", Version)
+	})
+	const project = "/api/v1/projects/{project}"
+	mux.HandleFunc("POST "+project+"/endpoints", s.createEndpoint)
+	mux.HandleFunc("GET "+project+"/endpoints", s.listEndpoints)
+	mux.HandleFunc("GET "+project+"/endpoints/{id}", s.getEndpoint)
+	mux.HandleFunc("PUT "+project+"/endpoints/{id}", s.updateEndpoint)
+	mux.HandleFunc("DELETE "+project+"/endpoints/{id}", s.deleteEndpoint)
+	mux.HandleFunc("PUT "+project+"/endpoints/{id}/pause", s.togglePause)
+	mux.HandleFunc("POST "+project+"/endpoints/{id}/activate", s.activateEndpoint)
+	mux.HandleFunc("POST "+project+"/subscriptions", s.createSubscription)
+	mux.HandleFunc("GET "+project+"/subscriptions", s.listSubscriptions)
+	mux.HandleFunc("GET "+project+"/subscriptions/{id}", s.getSubscription)
+	mux.HandleFunc("PUT "+project+"/subscriptions/{id}", s.updateSubscription)
+	mux.HandleFunc("DELETE "+project+"/subscriptions/{id}", s.deleteSubscription)
+	mux.HandleFunc("POST "+project+"/events", s.createEvent)
+	mux.HandleFunc("POST "+project+"/events/fanout", s.fanoutEvent)
+	mux.HandleFunc("GET "+project+"/events/{id}", s.getEvent)
+	mux.HandleFunc("GET "+project+"/eventdeliveries", s.listDeliveries)
+	mux.HandleFunc("GET "+project+"/eventdeliveries/{id}", s.getDelivery)
+	mux.HandleFunc("PUT "+project+"/eventdeliveries/{id}/resend", s.resendDelivery)
+
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Endpoints returns a snapshot of the stored endpoints in creation order.
+func (s *Server) Endpoints() []convoy.EndpointData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var endpoints []convoy.EndpointData
+	for _, id := range s.order {
+		if endpoint, ok := s.endpoints[id]; ok {
+			endpoints = append(endpoints, *endpoint)
+		}
+	}
+	return endpoints
+}
+
+// Events returns a snapshot of the created events in creation order.
+func (s *Server) Events() []convoy.EventData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []convoy.EventData
+	for _, id := range s.order {
+		if event, ok := s.events[id]; ok {
+			events = append(events, *event)
+		}
+	}
+	return events
+}
+
+// Subscriptions returns a snapshot of the stored subscriptions in creation
+// order.
+func (s *Server) Subscriptions() []convoy.SubscriptionData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subscriptions []convoy.SubscriptionData
+	for _, subscription := range s.subscriptions {
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions
+}
+
+func (s *Server) newID(kind string) string {
+	s.seq++
+	id := fmt.Sprintf("%s-%d", kind, s.seq)
+	s.order = append(s.order, id)
+	return id
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+type endpointParams struct {
+	Name               string                         `json:"name"`
+	URL                string                         `json:"url"`
+	AdvancedSignatures bool                           `json:"advanced_signatures"`
+	Authentication     *convoy.EndpointAuthentication `json:"authentication"`
+	Description        string                         `json:"description"`
+	HttpTimeout        int64                          `json:"http_timeout"`
+	IsDisabled         *bool                          `json:"is_disabled"`
+	OwnerID            string                         `json:"owner_id"`
+	RateLimit          int64                          `json:"rate_limit"`
+	RateLimitDuration  int64                          `json:"rate_limit_duration"`
+	Secret             string                         `json:"secret"`
+	SlackWebhookURL    string                         `json:"slack_webhook_url"`
+	SupportEmail       string                         `json:"support_email"`
+}
+
+func (p endpointParams) validate() string {
+	switch {
+	case p.Name == "":
+		return "please provide your endpoint name"
+	case p.URL == "":
+		return "please provide your url"
+	}
+	return ""
+}
+
+// apply replaces the settings of e with p, as Convoy does on both create
+// and update: fields missing from the body are cleared. The exceptions are
+// is_disabled, which changes the status only when present (true makes the
+// endpoint inactive and false active, undoing a pause), and secret, which
+// is replaced only when non-empty.
+func (p endpointParams) apply(e *convoy.EndpointData) {
+	e.Name, e.URL = p.Name, p.URL
+	e.AdvancedSignatures = p.AdvancedSignatures
+	e.Authentication = p.Authentication
+	e.Description = p.Description
+	e.HttpTimeout = p.HttpTimeout
+	e.OwnerID = p.OwnerID
+	e.RateLimit, e.RateLimitDuration = p.RateLimit, p.RateLimitDuration
+	e.SlackWebhookURL, e.SupportEmail = p.SlackWebhookURL, p.SupportEmail
+	if p.IsDisabled != nil {
+		e.Status = convoy.EndpointStatusActive
+		if *p.IsDisabled {
+			e.Status = convoy.EndpointStatusInactive
+		}
+	}
+	if p.Secret != "" {
+		e.Secrets = []convoy.EndpointSecret{{UID: "secret-" + e.UID, Value: p.Secret, CreatedAt: now()}}
+	}
+	e.UpdatedAt = now()
+}
+
+func (s *Server) createEndpoint(w http.ResponseWriter, r *http.Request) {
+	var params endpointParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		reply(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if msg := params.validate(); msg != "" {
+		reply(w, http.StatusBadRequest, msg, nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint := &convoy.EndpointData{
+		UID:       s.newID("endpoint"),
+		ProjectID: r.PathValue("project"),
+		Status:    convoy.EndpointStatusActive,
+		CreatedAt: now(),
+	}
+	params.apply(endpoint)
+	s.endpoints[endpoint.UID] = endpoint
+	reply(w, http.StatusCreated, "Endpoint created successfully", endpoint)
+}
+
+func (s *Server) listEndpoints(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ownerID, name := r.URL.Query().Get("ownerId"), r.URL.Query().Get("q")
+	endpoints := []convoy.EndpointData{}
+	for _, id := range s.order {
+		endpoint, ok := s.endpoints[id]
+		if !ok || endpoint.ProjectID != r.PathValue("project") {
+			continue
+		}
+		if ownerID != "" && endpoint.OwnerID != ownerID || name != "" && !strings.Contains(endpoint.Name, name) {
+			continue
+		}
+		endpoints = append(endpoints, *endpoint)
+	}
+	replyPage(w, "Endpoints fetched successfully", endpoints)
+}
+
+// lookupEndpoint returns the endpoint named in the request path, replying
+// 404 when it doesn't exist. The caller must hold s.mu.
+func (s *Server) lookupEndpoint(w http.ResponseWriter, r *http.Request) *convoy.EndpointData {
+	endpoint, ok := s.endpoints[r.PathValue("id")]
+	if !ok || endpoint.ProjectID != r.PathValue("project") {
+		reply(w, http.StatusNotFound, "endpoint not found", nil)
+		return nil
+	}
+	return endpoint
+}
+
+func (s *Server) getEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint := s.lookupEndpoint(w, r); endpoint != nil {
+		reply(w, http.StatusOK, "Endpoint fetched successfully", endpoint)
+	}
+}
+
+func (s *Server) updateEndpoint(w http.ResponseWriter, r *http.Request) {
+	var params endpointParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		reply(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if msg := params.validate(); msg != "" {
+		reply(w, http.StatusBadRequest, msg, nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint := s.lookupEndpoint(w, r); endpoint != nil {
+		params.apply(endpoint)
+		reply(w, http.StatusAccepted, "Endpoint updated successfully", endpoint)
+	}
+}
+
+func (s *Server) deleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint := s.lookupEndpoint(w, r); endpoint != nil {
+		delete(s.endpoints, endpoint.UID)
+		reply(w, http.StatusOK, "Endpoint deleted successfully", nil)
+	}
+}
+
+func (s *Server) togglePause(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint := s.lookupEndpoint(w, r)
+	if endpoint == nil {
+		return
+	}
+	switch endpoint.Status {
+	case convoy.EndpointStatusActive:
+		endpoint.Status = convoy.EndpointStatusPaused
+	case convoy.EndpointStatusPaused:
+		endpoint.Status = convoy.EndpointStatusActive
+	}
+	reply(w, http.StatusAccepted, "endpoint status updated successfully", endpoint)
+}
+
+func (s *Server) activateEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint := s.lookupEndpoint(w, r); endpoint != nil {
+		endpoint.Status = convoy.EndpointStatusActive
+		reply(w, http.StatusAccepted, "endpoint activated successfully", endpoint)
+	}
+}
+
+func (s *Server) createEvent(w http.ResponseWriter, r *http.Request) {
+	var params convoy.WebhookData
+	var data json.RawMessage
+	params.Data = &data
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		reply(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := r.PathValue("project")
+	targets := s.targets(project, params.EventType, func(e *convoy.EndpointData) bool {
+		return params.EndpointID == "" || e.UID == params.EndpointID
+	})
+	if params.EndpointID != "" && len(targets) == 0 {
+		reply(w, http.StatusNotFound, "endpoint not found", nil)
+		return
+	}
+	event := s.queueEvent(project, params.EventType, params.IdempotencyKey, data, targets)
+	reply(w, http.StatusCreated, "Event queued successfully", event)
+}
+
+func (s *Server) fanoutEvent(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		OwnerID        string          `json:"owner_id"`
+		EventType      string          `json:"event_type"`
+		IdempotencyKey string          `json:"idempotency_key"`
+		Data           json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		reply(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	if params.OwnerID == "" {
+		reply(w, http.StatusBadRequest, "please provide an owner id", nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	project := r.PathValue("project")
+	targets := s.targets(project, params.EventType, func(e *convoy.EndpointData) bool {
+		return e.OwnerID == params.OwnerID
+	})
+	event := s.queueEvent(project, params.EventType, params.IdempotencyKey, params.Data, targets)
+	reply(w, http.StatusCreated, "Event queued successfully", event)
+}
+
+// targets returns the IDs of the endpoints of project selected by match
+// that receive eventType: endpoints without subscriptions receive every
+// event, others only those their subscriptions' event types match. The
+// caller must hold s.mu.
+func (s *Server) targets(project, eventType string, match func(*convoy.EndpointData) bool) []string {
+	var targets []string
+	for _, id := range s.order {
+		endpoint, ok := s.endpoints[id]
+		if !ok || endpoint.ProjectID != project || !match(endpoint) {
+			continue
+		}
+		subscribed, wanted := false, false
+		for _, subscription := range s.subscriptions {
+			if subscription.EndpointID != id {
+				continue
+			}
+			subscribed = true
+			types := subscription.FilterConfig.EventTypes
+			wanted = wanted || len(types) == 0 || slices.Contains(types, "*") || slices.Contains(types, eventType)
+		}
+		if !subscribed || wanted {
+			targets = append(targets, id)
+		}
+	}
+	return targets
+}
+
+// queueEvent stores an event and delivers it successfully to targets,
+// returning the event as Convoy describes a created one. The caller must
+// hold s.mu.
+func (s *Server) queueEvent(project, eventType, idempotencyKey string, data json.RawMessage, targets []string) convoy.EventData {
+	event := &convoy.EventData{
+		UID:            s.newID("event"),
+		EventType:      eventType,
+		ProjectID:      project,
+		Endpoints:      targets,
+		IdempotencyKey: idempotencyKey,
+		Data:           data,
+		CreatedAt:      now(),
+		UpdatedAt:      now(),
+	}
+	s.events[event.UID] = event
+	for _, endpointID := range targets {
+		delivery := &convoy.EventDeliveryContent{
+			UID:        s.newID("delivery"),
+			CreatedAt:  now(),
+			EventID:    event.UID,
+			EndpointID: endpointID,
+			Status:     convoy.DeliveryStatusSuccess,
+		}
+		delivery.EventMetadata.EventType = event.EventType
+		delivery.Metadata.Data = data
+		delivery.Metadata.NumTrials = 1
+		s.deliveries[delivery.UID] = delivery
+	}
+
+	created := *event
+	created.Data = nil
+	return created
+}
+
+type subscriptionParams struct {
+	Name         string               `json:"name"`
+	EndpointID   string               `json:"endpoint_id"`
+	SourceID     string               `json:"source_id"`
+	FilterConfig *convoy.FilterConfig `json:"filter_config"`
+}
+
+// decode reads p from the request body, keeping filter numbers exact.
+func (p *subscriptionParams) decode(r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	return dec.Decode(p)
+}
+
+// apply sets the settings of s from p. An update may leave out everything
+// but the settings it changes.
+func (p subscriptionParams) apply(s *convoy.SubscriptionData) {
+	if p.Name != "" {
+		s.Name = p.Name
+	}
+	if p.EndpointID != "" {
+		s.EndpointID = p.EndpointID
+	}
+	if p.SourceID != "" {
+		s.SourceID = p.SourceID
+	}
+	if p.FilterConfig != nil {
+		s.FilterConfig = *p.FilterConfig
+	}
+	if len(s.FilterConfig.EventTypes) == 0 {
+		s.FilterConfig.EventTypes = []string{"*"}
+	}
+	s.UpdatedAt = now()
+}
+
+func (s *Server) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var params subscriptionParams
+	if err := params.decode(r); err != nil {
+		reply(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint, ok := s.endpoints[params.EndpointID]
+	if !ok || endpoint.ProjectID != r.PathValue("project") {
+		reply(w, http.StatusBadRequest, "failed to find endpoint by id", nil)
+		return
+	}
+	subscription := &convoy.SubscriptionData{
+		UID:       s.newID("subscription"),
+		Type:      "api",
+		ProjectID: r.PathValue("project"),
+		CreatedAt: now(),
+	}
+	params.apply(subscription)
+	s.subscriptions = append(s.subscriptions, subscription)
+	reply(w, http.StatusCreated, "Subscription created successfully", subscription)
+}
+
+func (s *Server) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpointID := r.URL.Query().Get("endpointId")
+	subscriptions := []convoy.SubscriptionData{}
+	for _, subscription := range s.subscriptions {
+		if subscription.ProjectID != r.PathValue("project") || endpointID != "" && subscription.EndpointID != endpointID {
+			continue
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	replyPage(w, "Subscriptions fetched successfully", subscriptions)
+}
+
+// lookupSubscription returns the index of the subscription named in the
+// request path, replying 404 and returning -1 when it doesn't exist. The
+// caller must hold s.mu.
+func (s *Server) lookupSubscription(w http.ResponseWriter, r *http.Request) int {
+	i := slices.IndexFunc(s.subscriptions, func(subscription *convoy.SubscriptionData) bool {
+		return subscription.UID == r.PathValue("id") && subscription.ProjectID == r.PathValue("project")
+	})
+	if i < 0 {
+		reply(w, http.StatusNotFound, "subscription not found", nil)
+	}
+	return i
+}
+
+func (s *Server) getSubscription(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.lookupSubscription(w, r); i >= 0 {
+		reply(w, http.StatusOK, "Subscription fetched successfully", s.subscriptions[i])
+	}
+}
+
+func (s *Server) updateSubscription(w http.ResponseWriter, r *http.Request) {
+	var params subscriptionParams
+	if err := params.decode(r); err != nil {
+		reply(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.lookupSubscription(w, r); i >= 0 {
+		params.apply(s.subscriptions[i])
+		reply(w, http.StatusAccepted, "Subscription updated successfully", s.subscriptions[i])
+	}
+}
+
+func (s *Server) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.lookupSubscription(w, r); i >= 0 {
+		s.subscriptions = slices.Delete(s.subscriptions, i, i+1)
+		reply(w, http.StatusOK, "Subscription deleted successfully", nil)
+	}
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event, ok := s.events[r.PathValue("id")]
+	if !ok || event.ProjectID != r.PathValue("project") {
+		reply(w, http.StatusNotFound, "event not found", nil)
+		return
+	}
+	reply(w, http.StatusOK, "Endpoint event fetched successfully", event)
+}
+
+func (s *Server) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := r.URL.Query()
+	deliveries := []convoy.EventDeliveryContent{}
+	for i := len(s.order) - 1; i >= 0; i-- {
+		delivery, ok := s.deliveries[s.order[i]]
+		if !ok || s.events[delivery.EventID].ProjectID != r.PathValue("project") {
+			continue
+		}
+		if v := query.Get("endpointId"); v != "" && delivery.EndpointID != v {
+			continue
+		}
+		if v := query.Get("eventId"); v != "" && delivery.EventID != v {
+			continue
+		}
+		if statuses := query["status"]; len(statuses) > 0 && !slices.Contains(statuses, string(delivery.Status)) {
+			continue
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	replyPage(w, "Event deliveries fetched successfully", deliveries)
+}
+
+func (s *Server) lookupDelivery(w http.ResponseWriter, r *http.Request) *convoy.EventDeliveryContent {
+	delivery, ok := s.deliveries[r.PathValue("id")]
+	if !ok || s.events[delivery.EventID].ProjectID != r.PathValue("project") {
+		reply(w, http.StatusNotFound, "event delivery not found", nil)
+		return nil
+	}
+	return delivery
+}
+
+func (s *Server) getDelivery(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if delivery := s.lookupDelivery(w, r); delivery != nil {
+		reply(w, http.StatusOK, "Event Delivery fetched successfully", delivery)
+	}
+}
+
+func (s *Server) resendDelivery(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if delivery := s.lookupDelivery(w, r); delivery != nil {
+		delivery.Status = convoy.DeliveryStatusSuccess
+		delivery.Metadata.NumTrials++
+		reply(w, http.StatusOK, "App event processed for retry successfully", delivery)
+	}
+}
+
+func reply(w http.ResponseWriter, status int, message string, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"status":  status < 400,
+		"message": message,
+		"data":    data,
+	})
+}
+
+func replyPage[T any](w http.ResponseWriter, message string, content []T) {
+	reply(w, http.StatusOK, message, convoy.Page[T]{
+		Content:    content,
+		Pagination: convoy.Pagination{PerPage: int64(len(content))},
+	})
+}
@@ -0,0 +1,158 @@
+package convoytest_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	convoy "github.com/formflake/convoy-go"
+	"github.com/formflake/convoy-go/convoytest"
+)
+
+func newClient(t *testing.T) (*convoytest.Server, convoy.WebhookInterface) {
+	t.Helper()
+	srv := convoytest.Start()
+	t.Cleanup(srv.Close)
+	client, err := convoy.NewWebhook(srv.URL, "key", "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client
+}
+
+func createEndpoint(t *testing.T, client convoy.WebhookInterface, params convoy.UpsertEndpointParams) string {
+	t.Helper()
+	created, err := client.CreateEndpoint("", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created.Data.Uid
+}
+
+func TestUpdateEndpointReplacesSettings(t *testing.T) {
+	srv, client := newClient(t)
+	id := createEndpoint(t, client, convoy.UpsertEndpointParams{
+		Name:         "billing",
+		URL:          "https://billing.example.com/hook",
+		OwnerID:      "owner-1",
+		SupportEmail: "ops@example.com",
+	})
+
+	_, err := client.UpdateEndpoint("", id, convoy.UpsertEndpointParams{
+		Name: "billing",
+		URL:  "https://billing.example.com/v2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := srv.Endpoints()[0]
+	if got.URL != "https://billing.example.com/v2" || got.OwnerID != "" || got.SupportEmail != "" {
+		t.Errorf("after update: url %q, owner %q, email %q; want new URL and cleared fields",
+			got.URL, got.OwnerID, got.SupportEmail)
+	}
+}
+
+func TestUpdateEndpointRequiresURL(t *testing.T) {
+	srv, client := newClient(t)
+	id := createEndpoint(t, client, convoy.UpsertEndpointParams{Name: "billing", URL: "https://billing.example.com/hook"})
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/api/v1/projects/project/endpoints/"+id,
+		strings.NewReader(`{"owner_id":"owner-2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	if got := srv.Endpoints()[0].OwnerID; got != "" {
+		t.Errorf("owner = %q, want unchanged", got)
+	}
+}
+
+func TestUpdateEndpointIsDisabled(t *testing.T) {
+	srv, client := newClient(t)
+	params := convoy.UpsertEndpointParams{Name: "billing", URL: "https://billing.example.com/hook"}
+	id := createEndpoint(t, client, params)
+	if _, err := client.PauseEndpoint("", id); err != nil {
+		t.Fatal(err)
+	}
+
+	// is_disabled is always sent by UpdateEndpoint, and false reactivates.
+	if _, err := client.UpdateEndpoint("", id, params); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Endpoints()[0].Status; got != convoy.EndpointStatusActive {
+		t.Errorf("status = %q, want %q", got, convoy.EndpointStatusActive)
+	}
+
+	params.IsDisabled = true
+	if _, err := client.UpdateEndpoint("", id, params); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Endpoints()[0].Status; got != convoy.EndpointStatusInactive {
+		t.Errorf("status = %q, want %q", got, convoy.EndpointStatusInactive)
+	}
+}
+
+func TestSubscriptionsRouteFanout(t *testing.T) {
+	srv, client := newClient(t)
+	orders := createEndpoint(t, client, convoy.UpsertEndpointParams{Name: "orders", URL: "https://a.example.com", OwnerID: "owner-1"})
+	all := createEndpoint(t, client, convoy.UpsertEndpointParams{Name: "all", URL: "https://b.example.com", OwnerID: "owner-1"})
+	createEndpoint(t, client, convoy.UpsertEndpointParams{Name: "other", URL: "https://c.example.com", OwnerID: "owner-2"})
+
+	_, err := client.CreateSubscription("", convoy.CreateSubscriptionParams{
+		Name:         "orders only",
+		EndpointID:   orders,
+		FilterConfig: &convoy.FilterConfig{EventTypes: []string{"order.created"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := client.ListSubscriptions("", convoy.SubscriptionQuery{EndpointID: orders})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data.Content) != 1 || len(srv.Subscriptions()) != 1 {
+		t.Fatalf("listed %d subscriptions, stored %d; want 1", len(list.Data.Content), len(srv.Subscriptions()))
+	}
+
+	for eventType, want := range map[string][]string{
+		"order.created":   {orders, all},
+		"invoice.created": {all},
+	} {
+		eventID, err := client.Publish(context.Background(), "owner-1", eventType, map[string]any{"id": 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		deliveries, err := client.ListDeliveriesForEvent("", eventID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, delivery := range deliveries {
+			got = append(got, delivery.EndpointID)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s delivered to %v, want %v", eventType, got, want)
+		}
+		for _, id := range want {
+			if !slices.Contains(got, id) {
+				t.Errorf("%s delivered to %v, want %v", eventType, got, want)
+			}
+		}
+	}
+
+	if err := client.DeleteSubscription("", list.Data.Content[0].UID); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Subscriptions()); n != 0 {
+		t.Errorf("%d subscriptions left after delete, want 0", n)
+	}
+}
@@ -0,0 +1,335 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DeliveryStatus is the state of an event delivery.
+type DeliveryStatus string
+
+// Event delivery statuses reported by Convoy.
+const (
+	DeliveryStatusScheduled  DeliveryStatus = "Scheduled"
+	DeliveryStatusProcessing DeliveryStatus = "Processing"
+	DeliveryStatusRetry      DeliveryStatus = "Retry"
+	DeliveryStatusSuccess    DeliveryStatus = "Success"
+	DeliveryStatusFailure    DeliveryStatus = "Failure"
+	DeliveryStatusDiscarded  DeliveryStatus = "Discarded"
+
+	// DeliveryStatusNotFound is reported by GetDeliveryStatuses for IDs
+	// that don't exist. Convoy never returns it.
+	DeliveryStatusNotFound DeliveryStatus = "NotFound"
+)
+
+// queryTimeFormat is the layout Convoy expects for date filters.
+const queryTimeFormat = "2006-01-02T15:04:05"
+
+// DeliveryQuery selects a page of event deliveries. Zero-valued fields are
+// not sent. At most one of NextPageCursor and PrevPageCursor should be set;
+// both are taken from the Pagination of a previous response.
+type DeliveryQuery struct {
+	// EndpointID restricts the results to one endpoint. When empty,
+	// deliveries across every endpoint in the project are returned.
+	EndpointID string
+	// EventID restricts the results to the deliveries of one event, one
+	// per endpoint it was sent to.
+	EventID   string
+	Status    []DeliveryStatus
+	StartDate time.Time
+	EndDate   time.Time
+
+	PerPage        int64
+	NextPageCursor string
+	PrevPageCursor string
+
+	// IncludePayload fills Metadata.Data of deliveries listed without their
+	// payload by fetching the events, with bounded concurrency.
+	IncludePayload bool
+}
+
+func (q DeliveryQuery) values() url.Values {
+	query := url.Values{}
+	if q.EndpointID != "" {
+		query.Set("endpointId", q.EndpointID)
+	}
+	if q.EventID != "" {
+		query.Set("eventId", q.EventID)
+	}
+	for _, status := range q.Status {
+		query.Add("status", string(status))
+	}
+	if !q.StartDate.IsZero() {
+		query.Set("startDate", q.StartDate.UTC().Format(queryTimeFormat))
+	}
+	if !q.EndDate.IsZero() {
+		query.Set("endDate", q.EndDate.UTC().Format(queryTimeFormat))
+	}
+	setPageParams(query, q.PerPage, q.NextPageCursor, q.PrevPageCursor)
+	return query
+}
+
+func (we *webhookData) ListEndpointEventDeliveries(projectID, endpointID string, query DeliveryQuery) (*EventDelivery, error) {
+	query.EndpointID = endpointID
+	return we.ListEventDeliveries(projectID, query)
+}
+
+func (we *webhookData) ListEventDeliveries(projectID string, query DeliveryQuery) (*EventDelivery, error) {
+	return we.listEventDeliveries(context.Background(), projectID, query)
+}
+
+func (we *webhookData) listEventDeliveries(ctx context.Context, projectID string, query DeliveryQuery) (*EventDelivery, error) {
+	page, err := listPage[EventDeliveryContent](ctx, we, "ListEventDeliveries", we.projectPath(projectID, "/eventdeliveries"), query.values())
+	if err != nil {
+		return nil, err
+	}
+	if query.IncludePayload {
+		if err := we.attachPayloads(ctx, projectID, page.Data.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	return (*EventDelivery)(page), nil
+}
+
+// ListDeliveriesForEvent returns every delivery spawned by the event, across
+// all the endpoints it was sent to, following pagination to the end.
+func (we *webhookData) ListDeliveriesForEvent(projectID, eventID string) ([]EventDeliveryContent, error) {
+	return listAll[EventDeliveryContent](context.Background(), we, "ListEventDeliveries",
+		we.projectPath(projectID, "/eventdeliveries"), DeliveryQuery{EventID: eventID}.values())
+}
+
+// attachPayloads sets Metadata.Data of the deliveries that lack it from their
+// events, fetching each event once.
+func (we *webhookData) attachPayloads(ctx context.Context, projectID string, deliveries []EventDeliveryContent) error {
+	missing := make(map[string][]*EventDeliveryContent)
+	for i := range deliveries {
+		if len(deliveries[i].Metadata.Data) == 0 && deliveries[i].EventID != "" {
+			missing[deliveries[i].EventID] = append(missing[deliveries[i].EventID], &deliveries[i])
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, bulkConcurrency)
+	for eventID, targets := range missing {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(eventID string, targets []*EventDeliveryContent) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			event, err := we.getEvent(ctx, projectID, eventID)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+			for _, delivery := range targets {
+				delivery.Metadata.Data = event.Data.Data
+			}
+		}(eventID, targets)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// CountEventDeliveries returns the number of deliveries matching query
+// without fetching them. Pagination fields of query are ignored.
+func (we *webhookData) CountEventDeliveries(projectID string, query DeliveryQuery) (int64, error) {
+	query.PerPage, query.NextPageCursor, query.PrevPageCursor = 0, "", ""
+
+	var count struct {
+		Data struct {
+			Num int64 `json:"num"`
+		} `json:"data"`
+	}
+	err := we.do(request{
+		op:      "CountEventDeliveries",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/eventdeliveries/countbatchretryevents"),
+		query:   query.values(),
+		timeout: 2 * time.Second,
+	}, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count.Data.Num, nil
+}
+
+type EventDeliveryResponse struct {
+	Message string               `json:"message"`
+	Status  bool                 `json:"status"`
+	Data    EventDeliveryContent `json:"data"`
+}
+
+func (we *webhookData) GetEventDelivery(projectID, deliveryID string) (*EventDeliveryResponse, error) {
+	return we.getEventDelivery(context.Background(), projectID, deliveryID)
+}
+
+func (we *webhookData) getEventDelivery(ctx context.Context, projectID, deliveryID string) (*EventDeliveryResponse, error) {
+	var delivery EventDeliveryResponse
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "GetEventDelivery",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/eventdeliveries/", deliveryID),
+		timeout: 2 * time.Second,
+	}, &delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveryStatuses fetches the current status of each delivery in ids
+// concurrently, keyed by delivery ID. IDs that don't exist map to
+// DeliveryStatusNotFound; any other failure fails the whole call.
+func (we *webhookData) GetDeliveryStatuses(ctx context.Context, projectID string, ids []string) (map[string]DeliveryStatus, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	statuses := make(map[string]DeliveryStatus, len(ids))
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			delivery, err := we.getEventDelivery(ctx, projectID, id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				statuses[id] = delivery.Data.Status
+			case errors.Is(err, ErrNotFound):
+				statuses[id] = DeliveryStatusNotFound
+			default:
+				if firstErr == nil {
+					firstErr = err
+				}
+				cancel()
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return statuses, nil
+}
+
+// ErrDeliveryNotSuccessful is returned by WaitForDeliverySuccess when the
+// delivery failed or did not succeed in time.
+var ErrDeliveryNotSuccessful = errors.New("convoy: delivery not successful")
+
+const deliveryPollInterval = time.Second
+
+// WaitForDeliverySuccess polls the delivery of eventID to endpointID until it
+// reaches DeliveryStatusSuccess. It returns an error wrapping
+// ErrDeliveryNotSuccessful, and carrying the last observed status, when the
+// delivery fails, is discarded, or timeout elapses first.
+func (we *webhookData) WaitForDeliverySuccess(ctx context.Context, projectID, endpointID, eventID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	delivery, err := we.pollDelivery(ctx, projectID, endpointID, eventID, func(status DeliveryStatus) bool {
+		switch status {
+		case DeliveryStatusSuccess, DeliveryStatusFailure, DeliveryStatusDiscarded:
+			return true
+		}
+		return false
+	})
+	status := DeliveryStatus("unknown")
+	if delivery != nil {
+		status = delivery.Data.Content[0].Status
+	}
+
+	switch {
+	case err != nil:
+		return fmt.Errorf("%w: event %s to endpoint %s still %s: %w",
+			ErrDeliveryNotSuccessful, eventID, endpointID, status, err)
+	case status != DeliveryStatusSuccess:
+		return fmt.Errorf("%w: event %s to endpoint %s ended with status %s",
+			ErrDeliveryNotSuccessful, eventID, endpointID, status)
+	}
+	return nil
+}
+
+// pollDelivery lists the delivery of eventID to endpointID every
+// deliveryPollInterval until done reports true for its status or ctx ends.
+// It returns the last page that contained the delivery, which may be nil.
+// Listing is retried on transient failures; any other error, such as
+// ErrUnauthorized or ErrNotFound, is returned at once.
+func (we *webhookData) pollDelivery(ctx context.Context, projectID, endpointID, eventID string, done func(status DeliveryStatus) bool) (*EventDelivery, error) {
+	var last *EventDelivery
+	for {
+		delivery, err := we.listEventDeliveries(ctx, projectID, DeliveryQuery{
+			EndpointID: endpointID,
+			EventID:    eventID,
+			PerPage:    1,
+		})
+		if err != nil && !transientPollError(err) {
+			return last, err
+		}
+		if err == nil && len(delivery.Data.Content) > 0 {
+			last = delivery
+			if done(delivery.Data.Content[0].Status) {
+				return last, nil
+			}
+		}
+
+		if err := we.clock.Sleep(ctx, deliveryPollInterval); err != nil {
+			return last, err
+		}
+	}
+}
+
+// transientPollError reports whether a failure to list deliveries may clear
+// up by the next poll: transport errors, timeouts, rate limiting and 5xx
+// responses. Other 4xx responses will not.
+func transientPollError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	}
+	return apiErr.StatusCode >= 500
+}
@@ -0,0 +1,152 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// instantClock is a Clock whose Sleep returns at once, advancing Now.
+type instantClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *instantClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *instantClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+	return ctx.Err()
+}
+
+// stubSequence answers each request with the next of responses, repeating
+// the last one.
+func stubSequence(responses ...roundTripFunc) (roundTripFunc, *int) {
+	var mu sync.Mutex
+	calls := 0
+	return func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		i := min(calls, len(responses)-1)
+		calls++
+		mu.Unlock()
+		return responses[i](r)
+	}, &calls
+}
+
+const deliveryPage = `{"status":true,"data":{"content":[{"uid":"dl-1","event_id":"ev-1","endpoint_id":"ep-1","status":%q}]}}`
+
+func TestWaitForDeliverySuccessRetriesTransientErrors(t *testing.T) {
+	transport, calls := stubSequence(
+		stubResponse(http.StatusServiceUnavailable, `{"message":"down"}`),
+		stubResponse(http.StatusOK, `{"status":true,"data":{"content":[]}}`),
+		stubResponse(http.StatusOK, fmt.Sprintf(deliveryPage, DeliveryStatusSuccess)),
+	)
+	client := newStubClient(t, transport, WithClock(&instantClock{}))
+	if err := client.WaitForDeliverySuccess(context.Background(), "", "ep-1", "ev-1", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if *calls != 3 {
+		t.Errorf("listed %d times, want 3", *calls)
+	}
+}
+
+func TestWaitForDeliverySuccessStopsOnPermanentErrors(t *testing.T) {
+	for _, c := range []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusBadRequest, nil},
+	} {
+		t.Run(http.StatusText(c.status), func(t *testing.T) {
+			transport, calls := stubSequence(stubResponse(c.status, `{"message":"no"}`))
+			client := newStubClient(t, transport, WithClock(&instantClock{}))
+			err := client.WaitForDeliverySuccess(context.Background(), "", "ep-1", "ev-1", time.Second)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != c.status || c.want != nil && !errors.Is(err, c.want) {
+				t.Errorf("err = %v, want the %d response", err, c.status)
+			}
+			if *calls != 1 {
+				t.Errorf("listed %d times, want 1", *calls)
+			}
+		})
+	}
+}
+
+func TestGetEventDeliveryFailure(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusOK, `{"status":true,"message":"Event Delivery fetched successfully","data":{
+		"uid":"dl-1","event_id":"ev-1","endpoint_id":"ep-1","status":"Failure",
+		"description":"dial tcp 10.0.0.7:443: connect: connection refused",
+		"event_metadata":{"event_type":"invoice.paid"},
+		"metadata":{"data":{"id":1},"num_trials":5,"retry_limit":5,"interval_seconds":20,"strategy":"exponential"},
+		"created_at":"2024-03-01T10:00:00.123Z","updated_at":"2024-03-01T10:05:00Z"}}`))
+
+	resp, err := client.GetEventDelivery("", "dl-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := resp.Data
+	if d.Status != DeliveryStatusFailure || d.Description != "dial tcp 10.0.0.7:443: connect: connection refused" {
+		t.Errorf("status %q, description %q; want the failure and its reason", d.Status, d.Description)
+	}
+	if d.Metadata.NumTrials != 5 || d.Metadata.RetryLimit != 5 || string(d.Metadata.Data) != `{"id":1}` {
+		t.Errorf("metadata = %+v", d.Metadata)
+	}
+}
+
+func TestListEventDeliveriesByEventID(t *testing.T) {
+	var queries []string
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		queries = append(queries, r.URL.RawQuery)
+		return stubResponse(http.StatusOK, fmt.Sprintf(deliveryPage, DeliveryStatusSuccess))(r)
+	})
+
+	if _, err := client.ListEventDeliveries("", DeliveryQuery{EventID: "ev-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListDeliveriesForEvent("", "ev-1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range queries {
+		if values, _ := url.ParseQuery(query); values.Get("eventId") != "ev-1" || values.Has("endpointId") {
+			t.Errorf("query = %q, want eventId=ev-1 and no endpoint filter", query)
+		}
+	}
+	if len(queries) != 2 {
+		t.Errorf("sent %d requests, want 2", len(queries))
+	}
+}
+
+func TestGetDeliveryStatuses(t *testing.T) {
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "/dl-gone") {
+			return stubResponse(http.StatusNotFound, `{"status":false,"message":"event delivery not found"}`)(r)
+		}
+		return stubResponse(http.StatusOK, `{"status":true,"data":{"uid":"dl-1","status":"Retry"}}`)(r)
+	})
+
+	statuses, err := client.GetDeliveryStatuses(context.Background(), "", []string{"dl-1", "dl-gone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]DeliveryStatus{"dl-1": DeliveryStatusRetry, "dl-gone": DeliveryStatusNotFound}
+	if !maps.Equal(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+}
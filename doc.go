@@ -0,0 +1,15 @@
+// Package convoy is a client for the Convoy webhooks gateway.
+//
+// Most applications only need Publish, which delivers an event to every
+// endpoint of one of their customers:
+//
+//	client, err := convoy.NewWebhook("https://convoy.example.com", apiKey, projectID)
+//	if err != nil {
+//		return err
+//	}
+//	eventID, err := client.Publish(ctx, customerID, "invoice.paid", invoice)
+//
+// The remaining methods manage endpoints, subscriptions and sources, and
+// inspect or retry event deliveries. They need Convoy MinServerVersion or
+// later, which can be checked at startup with RequireServerVersion.
+package convoy
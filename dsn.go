@@ -0,0 +1,62 @@
+package convoy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidDSN = errors.New("convoy: invalid DSN")
+
+// NewWebhookFromDSN creates a client from a single connection string:
+//
+//	convoy://<api-key>@<host>[:<port>][/<project-id>]
+//	convoy+http://<api-key>@<host>[:<port>][/<project-id>]
+//
+// The convoy scheme connects over https, convoy+http over plain http. The
+// API key must be URL-encoded if it contains reserved characters, a colon
+// included. The optional path segment becomes the default project. No query
+// parameters are recognised, so a DSN with any is rejected.
+func NewWebhookFromDSN(dsn string, opts ...Option) (*webhookService, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDSN, err)
+	}
+
+	var scheme string
+	switch u.Scheme {
+	case "convoy":
+		scheme = "https"
+	case "convoy+http":
+		scheme = "http"
+	default:
+		return nil, fmt.Errorf("%w: scheme must be convoy or convoy+http, got %q", ErrInvalidDSN, u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w: missing host", ErrInvalidDSN)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("%w: missing API key", ErrInvalidDSN)
+	}
+	if _, ok := u.User.Password(); ok {
+		return nil, fmt.Errorf("%w: API key contains an unencoded colon; URL-encode it as %%3A", ErrInvalidDSN)
+	}
+	if u.RawQuery != "" || u.ForceQuery {
+		query, _ := url.ParseQuery(u.RawQuery)
+		var names []string
+		for name := range query {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return nil, fmt.Errorf("%w: unknown query parameters %q", ErrInvalidDSN, names)
+	}
+	projectID := strings.Trim(u.Path, "/")
+	if strings.Contains(projectID, "/") {
+		return nil, fmt.Errorf("%w: path must be a single project ID, got %q", ErrInvalidDSN, u.Path)
+	}
+
+	baseURL := (&url.URL{Scheme: scheme, Host: u.Host}).String()
+	return NewWebhook(baseURL, u.User.Username(), projectID, opts...)
+}
@@ -0,0 +1,61 @@
+package convoy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EndpointAuthTypeAPIKey sends a static header with every delivery.
+const EndpointAuthTypeAPIKey = "api_key"
+
+// EndpointAuthentication is how Convoy authenticates itself to an endpoint.
+// Convoy has no arbitrary per-endpoint headers; a static API key header is
+// the one header it adds to every delivery. Per-event headers can be set
+// with WebhookData.CustomHeaders.
+type EndpointAuthentication struct {
+	Type   string          `json:"type"`
+	APIKey *EndpointAPIKey `json:"api_key,omitempty"`
+}
+
+type EndpointAPIKey struct {
+	HeaderName  string `json:"header_name"`
+	HeaderValue string `json:"header_value"`
+}
+
+// NewAPIKeyAuthentication returns authentication sending value in the header
+// name with every delivery.
+func NewAPIKeyAuthentication(name, value string) *EndpointAuthentication {
+	return &EndpointAuthentication{
+		Type:   EndpointAuthTypeAPIKey,
+		APIKey: &EndpointAPIKey{HeaderName: name, HeaderValue: value},
+	}
+}
+
+func (a *EndpointAuthentication) validate() error {
+	if a == nil || a.APIKey == nil {
+		return nil
+	}
+	if !validHeaderName(a.APIKey.HeaderName) {
+		return fmt.Errorf("convoy: invalid endpoint authentication header name %q", a.APIKey.HeaderName)
+	}
+	if strings.ContainsAny(a.APIKey.HeaderValue, "\r\n") {
+		return fmt.Errorf("convoy: endpoint authentication header %s has a line break in its value", a.APIKey.HeaderName)
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is an HTTP token (RFC 9110).
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range []byte(name) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
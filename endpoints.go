@@ -0,0 +1,269 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Endpoint statuses reported by Convoy. An endpoint is inactive when Convoy
+// disabled it after repeated delivery failures (its circuit breaker
+// tripped) and paused when someone paused it.
+const (
+	EndpointStatusActive   = "active"
+	EndpointStatusInactive = "inactive"
+	EndpointStatusPaused   = "paused"
+)
+
+// IsPaused reports whether the endpoint was paused manually.
+func (e EndpointData) IsPaused() bool {
+	return e.Status == EndpointStatusPaused
+}
+
+// IsCircuitBroken reports whether Convoy disabled the endpoint after
+// repeated delivery failures.
+func (e EndpointData) IsCircuitBroken() bool {
+	return e.Status == EndpointStatusInactive
+}
+
+type EndpointList struct {
+	Message string             `json:"message"`
+	Status  bool               `json:"status"`
+	Data    Page[EndpointData] `json:"data"`
+}
+
+// EndpointQuery filters ListEndpoints. Zero-valued fields are not sent.
+type EndpointQuery struct {
+	OwnerID string
+	// Name matches endpoints whose name contains it.
+	Name string
+
+	PerPage        int64
+	NextPageCursor string
+	PrevPageCursor string
+}
+
+func (q EndpointQuery) values() url.Values {
+	query := url.Values{}
+	if q.OwnerID != "" {
+		query.Set("ownerId", q.OwnerID)
+	}
+	if q.Name != "" {
+		query.Set("q", q.Name)
+	}
+	setPageParams(query, q.PerPage, q.NextPageCursor, q.PrevPageCursor)
+	return query
+}
+
+func (we *webhookData) ListEndpoints(projectID string, query EndpointQuery) (*EndpointList, error) {
+	return we.listEndpoints(context.Background(), projectID, query)
+}
+
+func (we *webhookData) listEndpoints(ctx context.Context, projectID string, query EndpointQuery) (*EndpointList, error) {
+	page, err := listPage[EndpointData](ctx, we, "ListEndpoints", we.projectPath(projectID, "/endpoints"), query.values())
+	if err != nil {
+		return nil, err
+	}
+	return (*EndpointList)(page), nil
+}
+
+// EndpointExists reports whether the endpoint exists. A 404 yields false
+// with a nil error; any other failure is returned as an error.
+func (we *webhookData) EndpointExists(projectID, endpointID string) (bool, error) {
+	_, err := we.GetEndpoint(projectID, endpointID)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// listAllEndpoints follows the pagination of ListEndpoints to the end.
+func (we *webhookData) listAllEndpoints(ctx context.Context, projectID string, query EndpointQuery) ([]EndpointData, error) {
+	return listAll[EndpointData](ctx, we, "ListEndpoints", we.projectPath(projectID, "/endpoints"), query.values())
+}
+
+// PauseEndpoint pauses the endpoint unless it is already paused, and returns
+// its resulting status. Unlike TogglePause it never reactivates an endpoint.
+func (we *webhookData) PauseEndpoint(projectID, endpointID string) (string, error) {
+	return we.pauseEndpoint(context.Background(), projectID, endpointID)
+}
+
+// PauseEndpointContext is PauseEndpoint with a context.
+func (we *webhookData) PauseEndpointContext(ctx context.Context, projectID, endpointID string) (string, error) {
+	return we.pauseEndpoint(ctx, projectID, endpointID)
+}
+
+func (we *webhookData) pauseEndpoint(ctx context.Context, projectID, endpointID string) (string, error) {
+	endpoint, err := we.getEndpoint(ctx, projectID, endpointID)
+	if err != nil {
+		return "", err
+	}
+	if endpoint.Data.Status == EndpointStatusPaused {
+		return EndpointStatusPaused, nil
+	}
+
+	return we.togglePause(ctx, projectID, endpointID)
+}
+
+// ActivateEndpoint activates a paused or inactive endpoint and returns its
+// resulting status.
+func (we *webhookData) ActivateEndpoint(projectID, endpointID string) (string, error) {
+	return we.activateEndpoint(context.Background(), projectID, endpointID)
+}
+
+// ActivateEndpointContext is ActivateEndpoint with a context.
+func (we *webhookData) ActivateEndpointContext(ctx context.Context, projectID, endpointID string) (string, error) {
+	return we.activateEndpoint(ctx, projectID, endpointID)
+}
+
+func (we *webhookData) activateEndpoint(ctx context.Context, projectID, endpointID string) (string, error) {
+	var endpoint EndpointToggleStatus
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "ActivateEndpoint",
+		method:  http.MethodPost,
+		path:    we.projectPath(projectID, "/endpoints/", endpointID, "/activate"),
+		timeout: 2 * time.Second,
+		// Activating an active endpoint changes nothing.
+		idempotent: true,
+	}, &endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint.Data.Status, nil
+}
+
+// SetEndpointPaused pauses or activates the endpoint and returns its
+// resulting status. Unlike TogglePause it names the desired state, so calling
+// it again, or retrying it, can't flip the endpoint the wrong way.
+func (we *webhookData) SetEndpointPaused(ctx context.Context, projectID, endpointID string, paused bool) (string, error) {
+	if paused {
+		return we.pauseEndpoint(ctx, projectID, endpointID)
+	}
+	return we.activateEndpoint(ctx, projectID, endpointID)
+}
+
+// ResetEndpointCircuitBreaker reactivates an endpoint Convoy disabled after
+// repeated failures, and returns its resulting status. It is ActivateEndpoint
+// restricted to circuit-broken endpoints: a paused endpoint is left paused
+// and reported as such.
+func (we *webhookData) ResetEndpointCircuitBreaker(projectID, endpointID string) (string, error) {
+	ctx := context.Background()
+
+	endpoint, err := we.getEndpoint(ctx, projectID, endpointID)
+	if err != nil {
+		return "", err
+	}
+	if !endpoint.Data.IsCircuitBroken() {
+		return endpoint.Data.Status, nil
+	}
+
+	return we.activateEndpoint(ctx, projectID, endpointID)
+}
+
+// GetEndpoints fetches the endpoints with the given IDs concurrently and
+// returns them in the order of ids. IDs that don't exist are omitted; any
+// other failure fails the whole call.
+func (we *webhookData) GetEndpoints(ctx context.Context, projectID string, ids []string) ([]EndpointData, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	found := make([]*EndpointData, len(ids))
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			endpoint, err := we.getEndpoint(ctx, projectID, id)
+			switch {
+			case err == nil:
+				found[i] = &endpoint.Data
+			case !errors.Is(err, ErrNotFound):
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	endpoints := make([]EndpointData, 0, len(ids))
+	for _, endpoint := range found {
+		if endpoint != nil {
+			endpoints = append(endpoints, *endpoint)
+		}
+	}
+	return endpoints, nil
+}
+
+// bulkConcurrency bounds the requests in flight for bulk helpers.
+const bulkConcurrency = 4
+
+// PauseEndpointsByOwner pauses every endpoint belonging to ownerID. It
+// returns one result per endpoint, holding its ID and the error pausing it
+// failed with; an error is returned only when the endpoints couldn't be
+// listed.
+func (we *webhookData) PauseEndpointsByOwner(ctx context.Context, projectID, ownerID string) ([]BatchResult, error) {
+	return we.forEachOwnerEndpoint(ctx, projectID, ownerID, we.pauseEndpoint)
+}
+
+// ActivateEndpointsByOwner activates every endpoint belonging to ownerID. It
+// returns one result per endpoint, holding its ID and the error activating
+// it failed with; an error is returned only when the endpoints couldn't be
+// listed.
+func (we *webhookData) ActivateEndpointsByOwner(ctx context.Context, projectID, ownerID string) ([]BatchResult, error) {
+	return we.forEachOwnerEndpoint(ctx, projectID, ownerID, we.activateEndpoint)
+}
+
+func (we *webhookData) forEachOwnerEndpoint(
+	ctx context.Context,
+	projectID, ownerID string,
+	fn func(ctx context.Context, projectID, endpointID string) (string, error),
+) ([]BatchResult, error) {
+	endpoints, err := we.listAllEndpoints(ctx, projectID, EndpointQuery{OwnerID: ownerID})
+	if err != nil {
+		return nil, err
+	}
+
+	results := runBatch(ctx, len(endpoints), func(ctx context.Context, i int) (string, error) {
+		_, err := fn(ctx, projectID, endpoints[i].UID)
+		return "", err
+	})
+	// Endpoints skipped when ctx ended are reported by ID too.
+	for i := range results {
+		results[i].ID = endpoints[i].UID
+	}
+	return results, nil
+}
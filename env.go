@@ -0,0 +1,38 @@
+package convoy
+
+import (
+	"fmt"
+	"os"
+)
+
+// Environment variables read by NewWebhookFromEnv.
+const (
+	EnvURL       = "CONVOY_URL"
+	EnvAPIKey    = "CONVOY_API_KEY"
+	EnvProjectID = "CONVOY_PROJECT_ID"
+)
+
+// NewWebhookFromEnv is like NewWebhook but reads the base URL from
+// CONVOY_URL, the API key from CONVOY_API_KEY and the optional default
+// project from CONVOY_PROJECT_ID. It fails if either of the first two is
+// unset. Call NewWebhook directly to supply values explicitly.
+func NewWebhookFromEnv(opts ...Option) (*webhookService, error) {
+	baseURL, err := requireEnv(EnvURL)
+	if err != nil {
+		return nil, err
+	}
+	key, err := requireEnv(EnvAPIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhook(baseURL, key, os.Getenv(EnvProjectID), opts...)
+}
+
+func requireEnv(name string) (string, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("convoy: environment variable %s is not set", name)
+	}
+	return value, nil
+}
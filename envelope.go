@@ -0,0 +1,85 @@
+package convoy
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsuccessful is returned by the Err method of a response whose envelope
+// reports failure. Convoy normally signals failure with the HTTP status,
+// which the client turns into an *APIError, so this mostly catches gateways
+// and success codes widened with WithSuccessStatusCodes.
+var ErrUnsuccessful = errors.New("convoy: response reports failure")
+
+func envelopeErr(status bool, message string) error {
+	if status {
+		return nil
+	}
+	if message == "" {
+		return ErrUnsuccessful
+	}
+	return fmt.Errorf("%w: %s", ErrUnsuccessful, message)
+}
+
+// Envelope is implemented by every response type, which all carry Convoy's
+// status flag and message.
+type Envelope interface {
+	Succeeded() bool
+	Err() error
+}
+
+// succeeder is implemented by pointers to the response types, so that an
+// empty success response, which has no envelope to decode, still reports
+// success.
+type succeeder interface {
+	setSucceeded()
+}
+
+func (r EndpointResponse) Succeeded() bool { return r.Status }
+func (r EndpointResponse) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r CreateEndpointResponse) Succeeded() bool { return r.Status }
+func (r CreateEndpointResponse) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r Endpoint) Succeeded() bool { return r.Status }
+func (r Endpoint) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r EventResponse) Succeeded() bool { return r.Status }
+func (r EventResponse) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r EventDelivery) Succeeded() bool { return r.Status }
+func (r EventDelivery) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r EventDeliveryResponse) Succeeded() bool { return r.Status }
+func (r EventDeliveryResponse) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r EndpointList) Succeeded() bool { return r.Status }
+func (r EndpointList) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r EventList) Succeeded() bool { return r.Status }
+func (r EventList) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r SourceList) Succeeded() bool { return r.Status }
+func (r SourceList) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r Subscription) Succeeded() bool { return r.Status }
+func (r Subscription) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r SubscriptionList) Succeeded() bool { return r.Status }
+func (r SubscriptionList) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r Project) Succeeded() bool { return r.Status }
+func (r Project) Err() error      { return envelopeErr(r.Status, r.Message) }
+
+func (r *EndpointResponse) setSucceeded()       { r.Status = true }
+func (r *CreateEndpointResponse) setSucceeded() { r.Status = true }
+func (r *Endpoint) setSucceeded()               { r.Status = true }
+func (r *EventResponse) setSucceeded()          { r.Status = true }
+func (r *EventDelivery) setSucceeded()          { r.Status = true }
+func (r *EventDeliveryResponse) setSucceeded()  { r.Status = true }
+func (r *EndpointList) setSucceeded()           { r.Status = true }
+func (r *EventList) setSucceeded()              { r.Status = true }
+func (r *SourceList) setSucceeded()             { r.Status = true }
+func (r *Subscription) setSucceeded()           { r.Status = true }
+func (r *SubscriptionList) setSucceeded()       { r.Status = true }
+func (r *Project) setSucceeded()                { r.Status = true }
@@ -0,0 +1,40 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestEnvelopeEmptySuccess(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusNoContent, ""))
+	resp, err := client.DeleteEndpoint("", "ep-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Succeeded() || resp.Err() != nil {
+		t.Errorf("Succeeded() = %v, Err() = %v; want an empty 204 to succeed", resp.Succeeded(), resp.Err())
+	}
+
+	client = newStubClient(t, stubResponse(http.StatusAccepted, ""))
+	we := client.WebhookInterface.(*webhookData)
+	event, err := we.createEvent(context.Background(), "", &Webhook{Data: WebhookData{EndpointID: "ep-1", EventType: "invoice.paid"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !event.Succeeded() {
+		t.Error("Succeeded() = false for an empty 202 to CreateEvent")
+	}
+}
+
+func TestEnvelopeReportedFailure(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusOK, `{"status":false,"message":"endpoint is being deleted"}`))
+	resp, err := client.DeleteEndpoint("", "ep-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Succeeded() || !errors.Is(resp.Err(), ErrUnsuccessful) {
+		t.Errorf("Succeeded() = %v, Err() = %v; want ErrUnsuccessful", resp.Succeeded(), resp.Err())
+	}
+}
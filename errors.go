@@ -0,0 +1,129 @@
+package convoy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound     = errors.New("convoy: not found")
+	ErrUnauthorized = errors.New("convoy: unauthorized")
+	ErrRateLimited  = errors.New("convoy: rate limited")
+	ErrConflict     = errors.New("convoy: conflict")
+
+	// ErrPayloadTooLarge is returned by CreateEvent, before anything is
+	// sent, when the encoded event exceeds the configured payload limit.
+	ErrPayloadTooLarge = errors.New("convoy: payload too large")
+
+	// ErrMalformedResponse is returned when Convoy accepted a request but
+	// its response could not be read or decoded, for instance because the
+	// connection dropped mid-body. Unlike a transport error, the request
+	// reached the server and may have taken effect.
+	ErrMalformedResponse = errors.New("convoy: malformed response")
+
+	// ErrInvalidURL is returned by NewWebhook for an unusable base URL and
+	// by UpdateEndpointURL for an unusable endpoint URL.
+	ErrInvalidURL = errors.New("convoy: invalid URL")
+
+	// ErrUnreachable and ErrWrongProject are returned by Warmup.
+	ErrUnreachable  = errors.New("convoy: server unreachable")
+	ErrWrongProject = errors.New("convoy: project not found or not accessible with this key")
+)
+
+const (
+	// maxErrorBody caps how much of an error response is read.
+	maxErrorBody = 64 << 10
+	// maxErrorSnippet caps how much of a non-JSON error body is quoted in
+	// the error message.
+	maxErrorSnippet = 256
+)
+
+// APIError is returned when Convoy answers with a status code that isn't
+// treated as success. It matches ErrNotFound, ErrUnauthorized,
+// ErrRateLimited and ErrConflict with errors.Is according to StatusCode.
+type APIError struct {
+	StatusCode  int
+	ContentType string
+	Message     string
+	Body        []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("response code %d invalid", e.StatusCode)
+	}
+	return fmt.Sprintf("response code %d invalid: %s", e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusConflict:
+		return ErrConflict
+	}
+	return nil
+}
+
+func (we *webhookData) checkPayloadSize(n int) error {
+	if we.maxPayloadBytes > 0 && n > we.maxPayloadBytes {
+		return fmt.Errorf("%w: %d bytes exceeds limit of %d bytes",
+			ErrPayloadTooLarge, n, we.maxPayloadBytes)
+	}
+	return nil
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+
+	body, err := readBody(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return apiErr
+	}
+	apiErr.Body = body
+
+	// A proxy or load balancer in front of Convoy may answer with an HTML
+	// page; quote the start of it rather than decoding it as JSON.
+	if !isJSON(apiErr.ContentType) {
+		snippet := strings.TrimSpace(string(body))
+		if len(snippet) > maxErrorSnippet {
+			snippet = truncate(snippet, maxErrorSnippet) + "..."
+		}
+		apiErr.Message = fmt.Sprintf("non-JSON response (%s): %s", apiErr.ContentType, snippet)
+		return apiErr
+	}
+
+	var envelope struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &envelope) == nil {
+		apiErr.Message = envelope.Message
+	}
+
+	return apiErr
+}
+
+// isJSON reports whether contentType is a JSON media type. A missing
+// Content-Type is treated as JSON.
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,31 @@
+package convoy
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestAPIErrorSnippetValidUTF8(t *testing.T) {
+	// 3-byte runes, so the 256-byte limit falls inside one.
+	page := "<html><body>" + strings.Repeat("€", 150) + "</body></html>"
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		resp, _ := stubResponse(http.StatusBadGateway, page)(r)
+		resp.Header.Set("Content-Type", "text/html; charset=utf-8")
+		return resp, nil
+	})
+
+	_, err := client.GetEndpoint("", "ep-1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an APIError", err)
+	}
+	if !utf8.ValidString(apiErr.Message) {
+		t.Errorf("message is not valid UTF-8: %q", apiErr.Message)
+	}
+	if !strings.HasSuffix(apiErr.Message, "...") || strings.Contains(apiErr.Message, "</html>") {
+		t.Errorf("message = %q, want the page truncated", apiErr.Message)
+	}
+}
@@ -0,0 +1,46 @@
+package convoy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// EventTyper is implemented by payload types that know their event type.
+type EventTyper interface {
+	EventType() string
+}
+
+// NewWebhookData returns the event data for sending v to endpointID, or to
+// the endpoints subscribed to the event type when endpointID is empty. An
+// empty eventType is taken from v if it implements EventTyper. v is kept as
+// is in Data, after checking that it encodes to JSON.
+func NewWebhookData(eventType string, v any, endpointID string) (*WebhookData, error) {
+	if eventType == "" {
+		if typer, ok := v.(EventTyper); ok {
+			eventType = typer.EventType()
+		}
+	}
+	if eventType == "" {
+		return nil, errors.New("convoy: event type undefined")
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return nil, fmt.Errorf("convoy: event data for %s: %w", eventType, err)
+	}
+
+	return &WebhookData{
+		Data:       v,
+		EventType:  eventType,
+		EndpointID: endpointID,
+	}, nil
+}
+
+// DecodePayload decodes an event payload, such as EventData.Data, into v.
+// Numbers decoded into interface values become json.Number rather than
+// float64, so 64-bit IDs survive.
+func DecodePayload(raw json.RawMessage, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
@@ -0,0 +1,45 @@
+package convoy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// ExportEventDeliveries writes every delivery matching query to w as
+// newline-delimited JSON, one page at a time, so memory use is bounded by the
+// page size. It returns the number of deliveries written. Output is flushed
+// after each page; when ctx is cancelled the export stops between pages and
+// everything written so far remains valid JSON lines.
+func (we *webhookData) ExportEventDeliveries(ctx context.Context, projectID string, query DeliveryQuery, w io.Writer) (int, error) {
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+
+	written := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		page, err := we.listEventDeliveries(ctx, projectID, query)
+		if err != nil {
+			return written, err
+		}
+		for _, delivery := range page.Data.Content {
+			if err := enc.Encode(delivery); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if err := buf.Flush(); err != nil {
+			return written, err
+		}
+
+		if !page.Data.Pagination.HasNextPage {
+			return written, nil
+		}
+		query.NextPageCursor = page.Data.Pagination.NextPageCursor
+		query.PrevPageCursor = ""
+	}
+}
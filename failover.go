@@ -0,0 +1,89 @@
+package convoy
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// failover tracks the base URLs a request may be sent to and the one that
+// last answered. It is shared by clients derived through ForProject.
+type failover struct {
+	urls []string
+
+	mu        sync.Mutex
+	preferred int
+}
+
+// order returns the indexes of urls starting with the preferred one.
+func (f *failover) order() []int {
+	f.mu.Lock()
+	start := f.preferred
+	f.mu.Unlock()
+
+	order := make([]int, len(f.urls))
+	for i := range order {
+		order[i] = (start + i) % len(f.urls)
+	}
+	return order
+}
+
+func (f *failover) prefer(i int) {
+	f.mu.Lock()
+	f.preferred = i
+	f.mu.Unlock()
+}
+
+// roundTripFailover is roundTrip across the failover URLs: when every
+// attempt against one URL fails with a transport error or a 5xx response,
+// a request that is safe to repeat moves on to the next URL. The URL that
+// answers becomes the first tried by later requests.
+func (we *webhookData) roundTripFailover(r request) (*http.Response, error) {
+	if we.failover == nil {
+		return we.roundTrip(r)
+	}
+
+	var err error
+	for _, i := range we.failover.order() {
+		if err != nil {
+			if !failoverSafe(r, err) {
+				break
+			}
+			we.logger.Warn("failing over to next Convoy URL", "from", r.baseURL, "op", r.op, "err", err)
+		}
+
+		r.baseURL = we.failover.urls[i]
+		var resp *http.Response
+		if resp, err = we.roundTrip(r); err == nil {
+			we.failover.prefer(i)
+			return resp, nil
+		}
+	}
+	return nil, err
+}
+
+// failoverSafe reports whether r may be sent to another URL after err.
+func failoverSafe(r request, err error) bool {
+	if r.method != http.MethodGet && r.idempotencyKey == "" && !r.idempotent || r.stream != nil {
+		return false
+	}
+	if r.ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+func (f *failover) validate() error {
+	for i, u := range f.urls {
+		if err := validateURL(u); err != nil {
+			return err
+		}
+		f.urls[i] = strings.TrimSuffix(u, "/")
+	}
+	return nil
+}
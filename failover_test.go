@@ -0,0 +1,145 @@
+package convoy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// countingServer answers every request with status and body and counts the
+// requests it receives.
+func countingServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestFailover(t *testing.T) {
+	for _, c := range []struct {
+		name          string
+		primary       int
+		post          bool
+		wantStatus    int // of the APIError returned, 0 for success
+		wantSecondary int32
+	}{
+		{"5xx fails over", http.StatusServiceUnavailable, false, 0, 1},
+		{"4xx does not", http.StatusNotFound, false, http.StatusNotFound, 0},
+		{"unkeyed POST does not", http.StatusServiceUnavailable, true, http.StatusServiceUnavailable, 0},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			primary, primaryHits := countingServer(t, c.primary, `{"status":false,"message":"unavailable"}`)
+			secondary, secondaryHits := countingServer(t, http.StatusOK, endpointJSON)
+			client, err := NewWebhook(primary.URL, "key", "project", WithFailoverURLs([]string{secondary.URL}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.post {
+				err = client.CreateEvent("", &Webhook{Data: WebhookData{EndpointID: "ep-1", EventType: "invoice.paid"}})
+			} else {
+				_, err = client.GetEndpoint("", "ep-1")
+			}
+			var apiErr *APIError
+			switch {
+			case c.wantStatus == 0 && err != nil:
+				t.Fatal(err)
+			case c.wantStatus != 0 && (!errors.As(err, &apiErr) || apiErr.StatusCode != c.wantStatus):
+				t.Fatalf("err = %v, want a %d APIError", err, c.wantStatus)
+			}
+			if primaryHits.Load() != 1 || secondaryHits.Load() != c.wantSecondary {
+				t.Errorf("primary got %d requests, secondary %d; want 1 and %d",
+					primaryHits.Load(), secondaryHits.Load(), c.wantSecondary)
+			}
+		})
+	}
+}
+
+func TestFailoverPrefersLastAnsweringURL(t *testing.T) {
+	primary, primaryHits := countingServer(t, http.StatusBadGateway, `{"status":false,"message":"bad gateway"}`)
+	secondary, secondaryHits := countingServer(t, http.StatusOK, endpointJSON)
+	client, err := NewWebhook(primary.URL, "key", "project", WithFailoverURLs([]string{secondary.URL}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if _, err := client.GetEndpoint("", "ep-1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if primaryHits.Load() != 1 || secondaryHits.Load() != 2 {
+		t.Errorf("primary got %d requests, secondary %d; want 1 and 2", primaryHits.Load(), secondaryHits.Load())
+	}
+}
+
+func TestRetryDecider(t *testing.T) {
+	for _, c := range []struct {
+		name         string
+		status       int
+		retry        bool
+		wantHits     int32
+		wantAttempts []int // seen by the decider; not called once WithRetry is spent
+	}{
+		{"retries what the decider accepts", http.StatusConflict, true, 3, []int{1, 2}},
+		{"skips what the decider rejects", http.StatusServiceUnavailable, false, 1, []int{1}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			srv, hits := countingServer(t, c.status, `{"status":false,"message":"busy"}`)
+			var attempts []int
+			client, err := NewWebhook(srv.URL, "key", "project",
+				WithRetry(2),
+				WithClock(&instantClock{}),
+				WithRetryDecider(func(resp *http.Response, err error, attempt int) bool {
+					attempts = append(attempts, attempt)
+					return c.retry && resp != nil && resp.StatusCode == c.status
+				}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.GetEndpoint("", "ep-1"); err == nil {
+				t.Fatal("GetEndpoint succeeded against a failing server")
+			}
+			if n := hits.Load(); n != c.wantHits {
+				t.Errorf("server got %d requests, want %d", n, c.wantHits)
+			}
+			if !slices.Equal(attempts, c.wantAttempts) {
+				t.Errorf("decider saw attempts %v, want %v", attempts, c.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	srv, hits := countingServer(t, http.StatusOK, endpointJSON)
+	client, err := NewWebhook(srv.URL, "key", "project", WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.CreateEvent("", &Webhook{Data: WebhookData{EndpointID: "ep-1", EventType: "invoice.paid"}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := hits.Load(); n != 0 {
+		t.Fatalf("dry-run POST reached the server %d times", n)
+	}
+
+	endpoint, err := client.GetEndpoint("", "ep-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := hits.Load(); n != 1 || endpoint.Data.Name != "billing" {
+		t.Errorf("GET reached the server %d times and returned %q; want 1 and billing", n, endpoint.Data.Name)
+	}
+}
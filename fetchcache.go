@@ -0,0 +1,58 @@
+package convoy
+
+import (
+	"context"
+	"sync"
+)
+
+// fetchCache caches the first successful fetch of a value per key. Callers
+// asking for a key whose fetch is in flight wait for it rather than send
+// their own request, and the lock is never held across a fetch, so a slow
+// server doesn't block callers of other keys or cancelled callers.
+type fetchCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	values   map[K]V
+	fetching map[K]chan struct{}
+}
+
+// get returns the cached value of key, calling fetch if there is none. When
+// a fetch fails, the callers waiting on it try again with their own.
+func (c *fetchCache[K, V]) get(ctx context.Context, key K, fetch func() (V, error)) (V, error) {
+	for {
+		c.mu.Lock()
+		if value, ok := c.values[key]; ok {
+			c.mu.Unlock()
+			return value, nil
+		}
+		if done, ok := c.fetching[key]; ok {
+			c.mu.Unlock()
+			select {
+			case <-done:
+				continue
+			case <-ctx.Done():
+				var zero V
+				return zero, ctx.Err()
+			}
+		}
+		done := make(chan struct{})
+		if c.fetching == nil {
+			c.fetching = make(map[K]chan struct{})
+		}
+		c.fetching[key] = done
+		c.mu.Unlock()
+
+		value, err := fetch()
+
+		c.mu.Lock()
+		if err == nil {
+			if c.values == nil {
+				c.values = make(map[K]V)
+			}
+			c.values[key] = value
+		}
+		delete(c.fetching, key)
+		close(done)
+		c.mu.Unlock()
+		return value, err
+	}
+}
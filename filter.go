@@ -0,0 +1,81 @@
+package convoy
+
+// Filter operators understood by Convoy.
+const (
+	FilterEq    = "$eq"
+	FilterNeq   = "$neq"
+	FilterGt    = "$gt"
+	FilterGte   = "$gte"
+	FilterLt    = "$lt"
+	FilterLte   = "$lte"
+	FilterIn    = "$in"
+	FilterNin   = "$nin"
+	FilterExist = "$exist"
+	FilterRegex = "$regex"
+)
+
+// FilterBuilder assembles a Filter. Body paths use dot notation for nested
+// fields, e.g. "customer.tier".
+//
+//	filter := convoy.NewFilter().
+//		Header("X-Event-Version", "2").
+//		BodyOp("amount", convoy.FilterGte, 100).
+//		Build()
+type FilterBuilder struct {
+	filter Filter
+}
+
+func NewFilter() *FilterBuilder {
+	return &FilterBuilder{}
+}
+
+// Header matches events whose header name equals value.
+func (b *FilterBuilder) Header(name string, value any) *FilterBuilder {
+	b.filter.Headers = setFilter(b.filter.Headers, name, value)
+	return b
+}
+
+// HeaderOp matches events whose header name satisfies op against value.
+func (b *FilterBuilder) HeaderOp(name, op string, value any) *FilterBuilder {
+	b.filter.Headers = setFilterOp(b.filter.Headers, name, op, value)
+	return b
+}
+
+// Body matches events whose body field at path equals value.
+func (b *FilterBuilder) Body(path string, value any) *FilterBuilder {
+	b.filter.Body = setFilter(b.filter.Body, path, value)
+	return b
+}
+
+// BodyOp matches events whose body field at path satisfies op against value.
+func (b *FilterBuilder) BodyOp(path, op string, value any) *FilterBuilder {
+	b.filter.Body = setFilterOp(b.filter.Body, path, op, value)
+	return b
+}
+
+func (b *FilterBuilder) Build() Filter {
+	return b.filter
+}
+
+func setFilter(m map[string]any, key string, value any) map[string]any {
+	if m == nil {
+		m = make(map[string]any)
+	}
+	m[key] = value
+	return m
+}
+
+// setFilterOp adds op to the operators for key, so several operators can
+// apply to one field, e.g. a range.
+func setFilterOp(m map[string]any, key, op string, value any) map[string]any {
+	if m == nil {
+		m = make(map[string]any)
+	}
+	ops, ok := m[key].(map[string]any)
+	if !ok {
+		ops = make(map[string]any)
+		m[key] = ops
+	}
+	ops[op] = value
+	return m
+}
@@ -0,0 +1,54 @@
+package convoy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateSubscriptionHeaderAndBodyFilters(t *testing.T) {
+	var sent map[string]any
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Error(err)
+		}
+		return stubResponse(http.StatusCreated, `{"status":true,"data":{"uid":"sub-1"}}`)(r)
+	})
+
+	filter := NewFilter().
+		Header("X-Event-Version", "2").
+		Body("customer.tier", "gold").
+		BodyOp("amount", FilterGte, 100).
+		BodyOp("amount", FilterLt, 1000).
+		Build()
+	_, err := client.CreateSubscription("", CreateSubscriptionParams{
+		Name:         "v2 invoices",
+		EndpointID:   "ep-1",
+		FilterConfig: &FilterConfig{EventTypes: []string{"invoice.paid"}, Filter: filter},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want map[string]any
+	json.Unmarshal([]byte(`{
+		"name": "v2 invoices",
+		"endpoint_id": "ep-1",
+		"filter_config": {
+			"event_types": ["invoice.paid"],
+			"filter": {
+				"headers": {"X-Event-Version": "2"},
+				"body": {"customer.tier": "gold", "amount": {"$gte": 100, "$lt": 1000}}
+			}
+		}
+	}`), &want)
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("sent %v\nwant %v", sent, want)
+	}
+}
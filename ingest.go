@@ -0,0 +1,33 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// IngestEvent sends body to the ingest URL of the incoming source with the
+// given mask ID (SourceData.MaskID), as an external provider would. Convoy
+// verifies the request against the source's verifier, so header must carry
+// whatever it expects, such as an HMAC signature of body, and attributes the
+// resulting event to the source. Events for a source can't be created through
+// CreateEvent, which has no source field.
+//
+// The client's API key is not sent: a source verifier may use the
+// Authorization header itself. Content-Type defaults to the client's
+// content type when header doesn't set one.
+func (we *webhookData) IngestEvent(ctx context.Context, maskID string, body []byte, header http.Header) error {
+	if err := we.checkPayloadSize(len(body)); err != nil {
+		return err
+	}
+	return we.do(request{
+		ctx:     ctx,
+		op:      "IngestEvent",
+		method:  http.MethodPost,
+		path:    "/ingest/" + maskID,
+		header:  header,
+		body:    body,
+		timeout: 5 * time.Second,
+		noAuth:  true,
+	}, nil)
+}
@@ -0,0 +1,56 @@
+package convoy
+
+import "context"
+
+// InstanceConfig holds the limits the Convoy server enforces on events sent
+// to a project.
+type InstanceConfig struct {
+	// MaxPayloadBytes is the largest event payload the server reads.
+	MaxPayloadBytes int64
+	RateLimit       ProjectRateLimit
+	// RetryStrategy caps how often a failed delivery is retried.
+	RetryStrategy ProjectRetryStrategy
+}
+
+// Options returns the client options that make client-side validation match
+// the server, for use with Clone.
+func (c *InstanceConfig) Options() []Option {
+	var opts []Option
+	if c.MaxPayloadBytes > 0 {
+		opts = append(opts, WithMaxPayloadBytes(int(c.MaxPayloadBytes)))
+	}
+	return opts
+}
+
+// instanceConfigs caches InstanceConfig by project. It is shared by clients
+// derived through ForProject and Clone.
+type instanceConfigs = fetchCache[string, InstanceConfig]
+
+// GetInstanceConfig returns the limits the server enforces for the client's
+// default project, as set by NewWebhook or ForProject. Convoy has no
+// instance-wide configuration endpoint, so they are read from the project's
+// configuration. The first successful answer is cached for the client's
+// life; concurrent first calls share one request.
+//
+// To validate against them, derive a client:
+//
+//	config, err := client.GetInstanceConfig(ctx)
+//	...
+//	client, err = client.Clone(config.Options()...)
+func (we *webhookData) GetInstanceConfig(ctx context.Context) (*InstanceConfig, error) {
+	config, err := we.limits.get(ctx, we.defaultProject, func() (InstanceConfig, error) {
+		project, err := we.getProject(ctx, we.defaultProject)
+		if err != nil {
+			return InstanceConfig{}, err
+		}
+		return InstanceConfig{
+			MaxPayloadBytes: project.Data.Config.MaxPayloadReadSize,
+			RateLimit:       project.Data.Config.RateLimit,
+			RetryStrategy:   project.Data.Config.Strategy,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
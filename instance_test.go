@@ -0,0 +1,46 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetInstanceConfigCachedPerProject(t *testing.T) {
+	var requests atomic.Int32
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		size := "1024"
+		if strings.HasSuffix(r.URL.Path, "/other") {
+			size = "2048"
+		}
+		return stubResponse(http.StatusOK, `{"status":true,"data":{"uid":"p","config":{"max_payload_read_size":`+size+`}}}`)(r)
+	})
+	other := client.ForProject("other")
+
+	var wg sync.WaitGroup
+	for range 10 {
+		for c, want := range map[*webhookService]int64{client: 1024, other: 2048} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				config, err := c.GetInstanceConfig(context.Background())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if config.MaxPayloadBytes != want {
+					t.Errorf("MaxPayloadBytes = %d, want %d", config.MaxPayloadBytes, want)
+				}
+				config.MaxPayloadBytes = 0 // must not reach the cache
+			}()
+		}
+	}
+	wg.Wait()
+	if n := requests.Load(); n != 2 {
+		t.Errorf("sent %d requests, want 1 per project", n)
+	}
+}
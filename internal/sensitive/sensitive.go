@@ -0,0 +1,22 @@
+// Package sensitive lists the JSON keys whose values convoy-go never shows,
+// neither in debug logs nor in convoyfmt output.
+package sensitive
+
+import "strings"
+
+// fields are endpoint and source secrets, credentials and tokens.
+var fields = map[string]bool{
+	"secret":        true,
+	"secrets":       true,
+	"password":      true,
+	"api_key":       true,
+	"token":         true,
+	"authorization": true,
+	"header_value":  true,
+}
+
+// Field reports whether the value of the JSON key or header name must be
+// hidden. The comparison ignores case.
+func Field(key string) bool {
+	return fields[strings.ToLower(key)]
+}
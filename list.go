@@ -0,0 +1,78 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// List fetches every item of a paginated Convoy list, following the cursors
+// of the standard pagination envelope. path is relative to the project, such
+// as "/endpoints"; an empty projectID selects the client's default project.
+// query holds the filters; any cursor in it is the starting point.
+func List[T any](ctx context.Context, client *webhookService, projectID, path string, query url.Values) ([]T, error) {
+	we, ok := client.WebhookInterface.(*webhookData)
+	if !ok {
+		return nil, errors.New("convoy: List needs a client returned by NewWebhook")
+	}
+	return listAll[T](ctx, we, "List", we.projectPath(projectID, path), query)
+}
+
+// pageResponse is the envelope of a list response. EndpointList and the
+// other list types have the same fields and convert from it.
+type pageResponse[T any] struct {
+	Message string  `json:"message"`
+	Status  bool    `json:"status"`
+	Data    Page[T] `json:"data"`
+}
+
+func (r *pageResponse[T]) setSucceeded() { r.Status = true }
+
+// listPage fetches one page of the list at path, reporting it to metrics as
+// op.
+func listPage[T any](ctx context.Context, we *webhookData, op, path string, query url.Values) (*pageResponse[T], error) {
+	var page pageResponse[T]
+	err := we.do(request{
+		ctx:     ctx,
+		op:      op,
+		method:  http.MethodGet,
+		path:    path,
+		query:   query,
+		timeout: 2 * time.Second,
+	}, &page)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// listAll follows the pagination of the list at path to the end, reporting
+// each page to metrics as op.
+func listAll[T any](ctx context.Context, we *webhookData, op, path string, query url.Values) ([]T, error) {
+	query = cloneValues(query)
+
+	var all []T
+	for {
+		page, err := listPage[T](ctx, we, op, path, query)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Data.Content...)
+
+		if !page.Data.Pagination.HasNextPage {
+			return all, nil
+		}
+		query.Del("prev_page_cursor")
+		setPageParams(query, 0, page.Data.Pagination.NextPageCursor, "")
+	}
+}
+
+func cloneValues(values url.Values) url.Values {
+	clone := make(url.Values, len(values))
+	for key, value := range values {
+		clone[key] = append([]string(nil), value...)
+	}
+	return clone
+}
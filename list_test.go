@@ -0,0 +1,57 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+type portalLink struct {
+	UID       string   `json:"uid"`
+	Name      string   `json:"name"`
+	Endpoints []string `json:"endpoints"`
+}
+
+func TestListCustomType(t *testing.T) {
+	pages := map[string]string{
+		"": `{"status":true,"data":{"content":[{"uid":"pl-1","name":"acme","endpoints":["ep-1"]}],
+			"pagination":{"has_next_page":true,"next_page_cursor":"pl-1"}}}`,
+		"pl-1": `{"status":true,"data":{"content":[{"uid":"pl-2","name":"globex","endpoints":[]}],
+			"pagination":{"has_next_page":false}}}`,
+	}
+	var queries []url.Values
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		queries = append(queries, r.URL.Query())
+		if r.URL.Path != "/api/v1/projects/project/portal-links" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		return stubResponse(http.StatusOK, pages[r.URL.Query().Get("next_page_cursor")])(r)
+	})
+
+	links, err := List[portalLink](context.Background(), client, "", "/portal-links", url.Values{"q": {"a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []portalLink{
+		{UID: "pl-1", Name: "acme", Endpoints: []string{"ep-1"}},
+		{UID: "pl-2", Name: "globex", Endpoints: []string{}},
+	}
+	if !slices.EqualFunc(links, want, func(a, b portalLink) bool {
+		return a.UID == b.UID && a.Name == b.Name && slices.Equal(a.Endpoints, b.Endpoints)
+	}) {
+		t.Errorf("links = %+v, want %+v", links, want)
+	}
+	if len(queries) != 2 || queries[1].Get("q") != "a" || queries[1].Get("direction") != "next" {
+		t.Errorf("queries = %v, want the filter kept on the second page", queries)
+	}
+}
+
+func TestListForeignClient(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusOK, `{}`))
+	client.WebhookInterface = struct{ WebhookInterface }{client.WebhookInterface}
+	if _, err := List[portalLink](context.Background(), client, "", "/portal-links", nil); err == nil {
+		t.Error("List with a wrapped client succeeded")
+	}
+}
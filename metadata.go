@@ -0,0 +1,60 @@
+package convoy
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Convoy has no tags or labels on endpoints, so metadata is stored in the
+// description, after the human-readable text, on a final line starting with
+// metadataPrefix and holding the pairs in URL query encoding.
+const metadataPrefix = "\n\nmetadata: "
+
+// EncodeDescription returns an endpoint description holding text followed by
+// metadata, for UpsertEndpointParams.Description. Read it back with
+// EndpointData.Metadata and EndpointData.DescriptionText.
+func EncodeDescription(text string, metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return text
+	}
+	values := url.Values{}
+	for key, value := range metadata {
+		values.Set(key, value)
+	}
+	return text + metadataPrefix + values.Encode()
+}
+
+// splitDescription separates the text of a description from its encoded
+// metadata.
+func splitDescription(description string) (string, url.Values) {
+	i := strings.LastIndex(description, metadataPrefix)
+	if i < 0 || strings.Contains(description[i+len(metadataPrefix):], "\n") {
+		return description, nil
+	}
+	values, err := url.ParseQuery(description[i+len(metadataPrefix):])
+	if err != nil {
+		return description, nil
+	}
+	return description[:i], values
+}
+
+// Metadata returns the metadata stored in the description by
+// EncodeDescription, or nil if there is none.
+func (e EndpointData) Metadata() map[string]string {
+	_, values := splitDescription(e.Description)
+	if len(values) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(values))
+	for key := range values {
+		metadata[key] = values.Get(key)
+	}
+	return metadata
+}
+
+// DescriptionText returns the description without the metadata stored by
+// EncodeDescription.
+func (e EndpointData) DescriptionText() string {
+	text, _ := splitDescription(e.Description)
+	return text
+}
@@ -0,0 +1,20 @@
+package convoy
+
+import "time"
+
+// Metrics receives per-operation counters from the client. Operation names
+// match the client method that issued the request, e.g. "CreateEvent".
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	IncRequest(op string)
+	IncError(op string)
+	IncRetry(op string)
+	ObserveLatency(op string, d time.Duration)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) IncRequest(string)                    {}
+func (nopMetrics) IncError(string)                      {}
+func (nopMetrics) IncRetry(string)                      {}
+func (nopMetrics) ObserveLatency(string, time.Duration) {}
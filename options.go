@@ -0,0 +1,388 @@
+package convoy
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Option func(*webhookData)
+
+// WithLogger sets the logger used by the client. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(we *webhookData) {
+		if logger != nil {
+			we.logger = logger
+		}
+	}
+}
+
+// WithDryRun makes every mutating call (create, update, delete, pause and
+// event creation) log the request it would send instead of sending it. Those
+// calls return a synthetic success response whose message is DryRunMessage.
+// GET requests are still executed.
+func WithDryRun() Option {
+	return func(we *webhookData) {
+		we.dryRun = true
+	}
+}
+
+// WithResponseDebugLog logs the body of every CreateEvent response to the
+// client's logger at debug level, with secrets redacted and the size capped
+// as set by WithMaxLogBodyBytes. Off by default.
+func WithResponseDebugLog(enabled bool) Option {
+	return func(we *webhookData) {
+		we.responseDebugLog = enabled
+	}
+}
+
+// WithSuccessStatusCodes replaces the default 2xx success range for requests
+// made with the given HTTP method. Responses with any other status code are
+// returned as errors.
+func WithSuccessStatusCodes(method string, codes ...int) Option {
+	return func(we *webhookData) {
+		if we.successCodes == nil {
+			we.successCodes = make(map[string][]int)
+		}
+		we.successCodes[method] = codes
+	}
+}
+
+// WithRedirectPolicy controls how redirects returned by Convoy or a gateway
+// in front of it are handled. Defaults to RedirectPreserve.
+func WithRedirectPolicy(policy RedirectPolicy) Option {
+	return func(we *webhookData) {
+		we.redirectPolicy = policy
+	}
+}
+
+// WithMetrics reports request counts, errors, retries and latency to m.
+func WithMetrics(m Metrics) Option {
+	return func(we *webhookData) {
+		if m != nil {
+			we.metrics = m
+		}
+	}
+}
+
+// WithStrictDecoding makes decoding fail when a response contains fields the
+// SDK types don't model. Useful in CI to catch Convoy API changes early; the
+// default is to ignore unknown fields.
+func WithStrictDecoding() Option {
+	return func(we *webhookData) {
+		we.strictDecoding = true
+	}
+}
+
+// WithUseNumber decodes numbers in untyped response fields, such as
+// subscription filter values, as json.Number instead of float64, so large
+// integers keep their precision. Event payloads are returned as
+// json.RawMessage either way; decode them with DecodePayload.
+func WithUseNumber() Option {
+	return func(we *webhookData) {
+		we.useNumber = true
+	}
+}
+
+// WithCanonicalJSON encodes the bodies of CreateEvent, CreateRawEvent and
+// Publish with CanonicalJSON, so a payload signed as CanonicalJSON(data)
+// reaches Convoy byte for byte. Payloads of CreateEventFromReader are sent
+// as given.
+func WithCanonicalJSON() Option {
+	return func(we *webhookData) {
+		we.canonicalJSON = true
+	}
+}
+
+// DefaultMaxPayloadBytes matches the default request size limit of a Convoy
+// instance.
+const DefaultMaxPayloadBytes = 50 << 10
+
+// WithMaxPayloadBytes sets the largest encoded event CreateEvent will send.
+// Use it on deployments with a raised limit; n <= 0 disables the check.
+func WithMaxPayloadBytes(n int) Option {
+	return func(we *webhookData) {
+		we.maxPayloadBytes = n
+	}
+}
+
+// WithMaxLogBodyBytes caps the bodies the client logs, such as the requests
+// described in dry-run mode, at n bytes; n <= 0 disables the cap. Logged
+// bodies always have secrets, tokens and the Authorization header redacted.
+func WithMaxLogBodyBytes(n int) Option {
+	return func(we *webhookData) {
+		we.maxLogBody = n
+	}
+}
+
+// WithStreamThreshold sets the payload size above which
+// CreateEventFromReader streams the body instead of buffering it. Defaults
+// to DefaultStreamThreshold.
+func WithStreamThreshold(n int) Option {
+	return func(we *webhookData) {
+		if n >= 0 {
+			we.streamThreshold = n
+		}
+	}
+}
+
+// WithDefaultEventHeaders sets headers sent with every CreateEvent request,
+// such as a schema version. Headers passed in Webhook.Headers take
+// precedence over these, and neither can replace the Authorization header.
+// Other requests are unaffected.
+func WithDefaultEventHeaders(header http.Header) Option {
+	return func(we *webhookData) {
+		we.eventHeaders = header.Clone()
+	}
+}
+
+// WithAccept sets the Accept header sent with every request. Defaults to
+// application/json; responses must still be JSON.
+func WithAccept(mediaType string) Option {
+	return func(we *webhookData) {
+		we.accept = mediaType
+	}
+}
+
+// WithUserAgentSuffix appends s, such as "myapp/1.2.3", to the User-Agent
+// sent with every request, giving "convoy-go/<version> (myapp/1.2.3)".
+func WithUserAgentSuffix(s string) Option {
+	return func(we *webhookData) {
+		we.userAgentSuffix = s
+	}
+}
+
+// WithContentType sets the Content-Type of request bodies, for gateways that
+// expect a vendor JSON media type. Defaults to application/json. A
+// Content-Type in Webhook.Headers or WithDefaultEventHeaders takes
+// precedence for event creation.
+func WithContentType(mediaType string) Option {
+	return func(we *webhookData) {
+		we.contentType = mediaType
+	}
+}
+
+// WithRetry retries failed requests up to maxRetries times, waiting between
+// attempts as chosen by WithBackoff, after transport errors, 429 and 5xx
+// responses. Only requests that are safe to repeat are retried: GET
+// requests, requests with an idempotency key (keyed events and endpoints)
+// and ActivateEndpoint. TogglePause, and the toggle PauseEndpoint and
+// SetEndpointPaused may issue, are never retried. Retries are disabled by
+// default.
+func WithRetry(maxRetries int) Option {
+	return func(we *webhookData) {
+		we.maxRetries = maxRetries
+	}
+}
+
+// WithRetryDecider replaces the default choice of which failures WithRetry
+// retries: transport errors while the context is live, 429 and 5xx
+// responses. fn is called after each failed attempt with either the
+// rejected response or the transport error, and the number of attempts made
+// so far, starting at 1; it must not read or close the response body.
+// Requests that aren't safe to repeat are still never retried, and
+// WithRetry still caps the retries.
+func WithRetryDecider(fn func(resp *http.Response, err error, attempt int) bool) Option {
+	return func(we *webhookData) {
+		we.retryDecider = fn
+	}
+}
+
+// WithBackoff sets the delay between retries. Defaults to an
+// ExponentialBackoff from 500ms capped at 10s.
+func WithBackoff(b Backoff) Option {
+	return func(we *webhookData) {
+		if b != nil {
+			we.backoff = b
+		}
+	}
+}
+
+// WithClock replaces the real time source, so tests can observe retry
+// backoff and polling intervals without waiting.
+func WithClock(clock Clock) Option {
+	return func(we *webhookData) {
+		if clock != nil {
+			we.clock = clock
+		}
+	}
+}
+
+// WithProjectKey sets the project API key, replacing the key passed to
+// NewWebhook. It authorises every project-scoped method.
+func WithProjectKey(key string) Option {
+	return func(we *webhookData) {
+		_, pat := we.creds.get()
+		we.creds = &credentials{key: key, pat: pat}
+	}
+}
+
+// WithPersonalAccessToken sets a personal access token, used for
+// account-level methods such as GetProject. Project-scoped methods keep
+// using the project key and fall back to the token only when no project key
+// is configured.
+func WithPersonalAccessToken(token string) Option {
+	return func(we *webhookData) {
+		key, _ := we.creds.get()
+		we.creds = &credentials{key: key, pat: token}
+	}
+}
+
+// WithTimeout sets the timeout of every request, replacing the built-in
+// defaults of each method (mostly 2s). Zero means no timeout beyond the
+// caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(we *webhookData) {
+		we.timeout = d
+	}
+}
+
+// WithOperationTimeout sets the timeout of requests made by the named client
+// method, such as "CreateEndpoint", taking precedence over WithTimeout.
+// Methods built on others, such as PauseEndpoint, may use the timeouts of
+// those methods for some of their requests.
+func WithOperationTimeout(method string, d time.Duration) Option {
+	return func(we *webhookData) {
+		if we.opTimeouts == nil {
+			we.opTimeouts = make(map[string]time.Duration)
+		}
+		we.opTimeouts[method] = d
+	}
+}
+
+// WithCache stores GET responses in c and revalidates them with
+// If-None-Match and If-Modified-Since, using the stored body when Convoy
+// answers 304 Not Modified. Responses are stored only when they carry an
+// ETag, a Last-Modified date or a Cache-Control max-age, and never with
+// no-store; while max-age holds they are used without a request. Entries
+// are keyed by credential and base URL as well as path, so c may be shared
+// by clients with different keys. Caching is off by default.
+func WithCache(c Cache) Option {
+	return func(we *webhookData) {
+		we.cache = c
+	}
+}
+
+// WithEndpointNameTTL sets how long CreateEventByEndpointName caches the
+// endpoint a name resolved to. Defaults to DefaultEndpointNameTTL; d <= 0
+// resolves the name on every call.
+func WithEndpointNameTTL(d time.Duration) Option {
+	return func(we *webhookData) {
+		we.endpointNameTTL = d
+	}
+}
+
+// WithEventAttemptCallback calls fn before and after every attempt to
+// create an event, by CreateEvent, Publish and the other event creation
+// methods, including retries. fn runs on the calling goroutine and should
+// return quickly.
+func WithEventAttemptCallback(fn func(EventAttempt)) Option {
+	return func(we *webhookData) {
+		we.eventAttempt = fn
+	}
+}
+
+// WithMaxConcurrency caps the requests in progress at once, across all
+// methods and clients derived through ForProject, at n. Calls over the cap
+// wait for a slot or for their context to end. Unlimited by default.
+func WithMaxConcurrency(n int) Option {
+	return func(we *webhookData) {
+		if n > 0 {
+			we.inFlight = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithFailoverURLs adds base URLs of other Convoy deployments, tried in
+// order when a request to the current one fails with a transport error or a
+// 5xx response after any retries. Only requests that are safe to repeat fail
+// over: GET requests, requests with an idempotency key and ActivateEndpoint.
+// The URL that last answered is tried first by later requests. NewWebhook
+// fails if a URL is invalid.
+func WithFailoverURLs(urls []string) Option {
+	return func(we *webhookData) {
+		we.failover = &failover{urls: append([]string{we.url}, urls...)}
+	}
+}
+
+// WithTransport sends requests through rt instead of http.DefaultTransport.
+// Options that tune the transport, such as WithForceHTTP1, modify rt when it
+// is an *http.Transport and must come after this option.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(we *webhookData) {
+		we.transport = rt
+	}
+}
+
+// WithForceHTTP1 disables HTTP/2, for proxies that mishandle it. By default
+// Go negotiates HTTP/2 with https servers that support it.
+func WithForceHTTP1() Option {
+	return func(we *webhookData) {
+		if t := we.httpTransport(); t != nil {
+			t.ForceAttemptHTTP2 = false
+			t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		}
+	}
+}
+
+// WithMinTLSVersion sets the lowest TLS version the client accepts, such as
+// tls.VersionTLS12. Defaults to the minimum of the Go runtime's crypto/tls.
+func WithMinTLSVersion(version uint16) Option {
+	return func(we *webhookData) {
+		if t := we.httpTransport(); t != nil {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = &tls.Config{}
+			}
+			t.TLSClientConfig.MinVersion = version
+		}
+	}
+}
+
+// WithDialTimeout bounds how long establishing a TCP connection may take.
+// Defaults to 30 seconds, as for http.DefaultTransport.
+func WithDialTimeout(d time.Duration) Option {
+	return func(we *webhookData) {
+		if t := we.httpTransport(); t != nil {
+			dialer := &net.Dialer{Timeout: d, KeepAlive: 30 * time.Second}
+			t.DialContext = dialer.DialContext
+		}
+	}
+}
+
+// WithTLSHandshakeTimeout bounds how long the TLS handshake may take.
+// Defaults to 10 seconds, as for http.DefaultTransport.
+func WithTLSHandshakeTimeout(d time.Duration) Option {
+	return func(we *webhookData) {
+		if t := we.httpTransport(); t != nil {
+			t.TLSHandshakeTimeout = d
+		}
+	}
+}
+
+// WithResponseHeaderTimeout bounds how long to wait for the response headers
+// once the request is written, leaving reading the body to the request
+// timeout. By default only the request timeout applies.
+func WithResponseHeaderTimeout(d time.Duration) Option {
+	return func(we *webhookData) {
+		if t := we.httpTransport(); t != nil {
+			t.ResponseHeaderTimeout = d
+		}
+	}
+}
+
+// httpTransport returns the client's transport for tuning, first replacing
+// the default with a private clone of it. It returns nil if the transport
+// set by WithTransport isn't an *http.Transport.
+func (we *webhookData) httpTransport() *http.Transport {
+	if we.transport == nil {
+		we.transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	t, _ := we.transport.(*http.Transport)
+	if t != nil && we.sharedTransport {
+		t = t.Clone()
+		we.transport, we.sharedTransport = t, false
+	}
+	return t
+}
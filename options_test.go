@@ -0,0 +1,44 @@
+package convoy_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	convoy "github.com/formflake/convoy-go"
+)
+
+func TestWithUseNumber(t *testing.T) {
+	const large = "9007199254740993" // 2^53 + 1, which a float64 rounds
+	_, client := newFake(t, convoy.WithUseNumber())
+	endpoint, err := client.CreateEndpoint("", billingEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := client.CreateSubscription("", convoy.CreateSubscriptionParams{
+		Name:         "billing",
+		EndpointID:   endpoint.Data.Uid,
+		FilterConfig: &convoy.FilterConfig{Filter: convoy.Filter{Body: map[string]any{"account_id": json.Number(large)}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetSubscription("", created.Data.UID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := got.Data.FilterConfig.Filter.Body["account_id"]; n != json.Number(large) {
+		t.Errorf("GetSubscription account_id = %#v, want json.Number(%s)", n, large)
+	}
+
+	list, err := client.ListSubscriptions("", convoy.SubscriptionQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data.Content) != 1 {
+		t.Fatalf("listed %d subscriptions, want 1", len(list.Data.Content))
+	}
+	if n := list.Data.Content[0].FilterConfig.Filter.Body["account_id"]; n != json.Number(large) {
+		t.Errorf("ListSubscriptions account_id = %#v, want json.Number(%s)", n, large)
+	}
+}
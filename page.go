@@ -0,0 +1,21 @@
+package convoy
+
+import "reflect"
+
+// Page is the data of a list response. Depending on the version and route,
+// Convoy returns it either as {"content": [...], "pagination": {...}} or as a
+// bare array; both decode into Page, the latter with a zero Pagination.
+type Page[T any] struct {
+	Content    []T        `json:"content"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// page marks Page types for the decoder, which wraps a bare array into
+// {"content": [...]} before decoding it with the client's configuration.
+func (Page[T]) page() {}
+
+var pageType = reflect.TypeFor[interface{ page() }]()
+
+func isPage(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Implements(pageType)
+}
@@ -0,0 +1,55 @@
+package convoy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var pageShapes = map[string]string{
+	"object": `{"status":true,"data":{"content":[%s],"pagination":{"per_page":20}}}`,
+	"array":  `{"status":true,"data":[%s]}`,
+}
+
+func pageBody(shape, items string) string {
+	return fmt.Sprintf(pageShapes[shape], items)
+}
+
+func TestPageUseNumber(t *testing.T) {
+	for shape := range pageShapes {
+		t.Run(shape, func(t *testing.T) {
+			client := newStubClient(t, stubResponse(http.StatusOK, pageBody(shape, `{"id":9007199254740993}`)), WithUseNumber())
+			items, err := List[map[string]any](context.Background(), client, "", "/items", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != 1 || items[0]["id"] != json.Number("9007199254740993") {
+				t.Errorf("items = %v, want the id as json.Number 9007199254740993", items)
+			}
+		})
+	}
+}
+
+func TestPageStrictDecoding(t *testing.T) {
+	for shape := range pageShapes {
+		t.Run(shape, func(t *testing.T) {
+			body := pageBody(shape, `{"uid":"ep-1","created_at":"2024-03-01 10:00:00"}`)
+			client := newStubClient(t, stubResponse(http.StatusOK, body), WithStrictDecoding())
+			list, err := client.ListEndpoints("", EndpointQuery{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Data.Content) != 1 || list.Data.Content[0].UID != "ep-1" {
+				t.Errorf("content = %+v, want ep-1", list.Data.Content)
+			}
+
+			body = pageBody(shape, `{"uid":"ep-1","unknown_field":true}`)
+			client = newStubClient(t, stubResponse(http.StatusOK, body), WithStrictDecoding())
+			if _, err := client.ListEndpoints("", EndpointQuery{}); err == nil {
+				t.Error("strict decoding accepted an unknown field in a list item")
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package convoy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// endpointFields lists the JSON names of UpsertEndpointParams.
+var endpointFields = sync.OnceValue(func() []string {
+	var names []string
+	t := reflect.TypeFor[UpsertEndpointParams]()
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+})
+
+// UpdateEndpointPatch changes only the endpoint fields named in patch,
+// following JSON merge patch (RFC 7396) semantics: a null value clears the
+// field and an object is merged into the current one. Keys are the JSON
+// names of UpsertEndpointParams, such as "url" or "support_email"; an
+// unknown key fails the call before anything is sent.
+//
+// Convoy replaces the whole endpoint on update, so the endpoint is fetched
+// first and the patch is applied to its current settings. Its status only
+// changes when the patch sets "is_disabled".
+func (we *webhookData) UpdateEndpointPatch(projectID, endpointID string, patch map[string]any) (*EndpointResponse, error) {
+	for key := range patch {
+		if !slices.Contains(endpointFields(), key) {
+			return nil, fmt.Errorf("unknown endpoint field %q in patch", key)
+		}
+	}
+
+	endpoint, err := we.GetEndpoint(projectID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := json.Marshal(currentParams(endpoint.Data))
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	dec := json.NewDecoder(bytes.NewReader(current))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var update endpointUpdate
+	merged, err := json.Marshal(mergePatch(doc, patch))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(merged, &update); err != nil {
+		return nil, fmt.Errorf("invalid endpoint patch: %w", err)
+	}
+	return we.updateEndpoint(projectID, endpointID, update)
+}
+
+// mergePatch applies patch to target as described by RFC 7396.
+func mergePatch(target any, patch map[string]any) map[string]any {
+	doc, ok := target.(map[string]any)
+	if !ok {
+		doc = make(map[string]any, len(patch))
+	}
+	for key, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(doc, key)
+		case map[string]any:
+			doc[key] = mergePatch(doc[key], value)
+		default:
+			doc[key] = value
+		}
+	}
+	return doc
+}
@@ -0,0 +1,110 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type Project struct {
+	Message string      `json:"message"`
+	Status  bool        `json:"status"`
+	Data    ProjectData `json:"data"`
+}
+
+type ProjectData struct {
+	UID            string        `json:"uid"`
+	Name           string        `json:"name"`
+	LogoURL        string        `json:"logo_url"`
+	OrganisationID string        `json:"organisation_id"`
+	Type           string        `json:"type"`
+	Config         ProjectConfig `json:"config"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	DeletedAt      *time.Time    `json:"deleted_at"`
+}
+
+type ProjectConfig struct {
+	MaxPayloadReadSize             int64 `json:"max_payload_read_size"`
+	ReplayAttacksPreventionEnabled bool  `json:"replay_attacks_prevention_enabled"`
+	AddEventIDTraceHeaders         bool  `json:"add_event_id_trace_headers"`
+	// DisableEndpoint makes Convoy disable endpoints whose deliveries keep
+	// failing, as decided by CircuitBreaker: their status becomes
+	// EndpointStatusInactive until ActivateEndpoint is called.
+	DisableEndpoint               bool   `json:"disable_endpoint"`
+	MultipleEndpointSubscriptions bool   `json:"multiple_endpoint_subscriptions"`
+	SearchPolicy                  string `json:"search_policy"`
+
+	RateLimit ProjectRateLimit     `json:"ratelimit"`
+	Strategy  ProjectRetryStrategy `json:"strategy"`
+	Signature ProjectSignature     `json:"signature"`
+	// CircuitBreaker is reported by servers that support circuit breaking.
+	CircuitBreaker *ProjectCircuitBreaker `json:"circuit_breaker"`
+}
+
+// ProjectCircuitBreaker holds the thresholds at which Convoy trips an
+// endpoint's circuit breaker. With DisableEndpoint set, a tripped endpoint is
+// disabled; otherwise deliveries to it are only held back while it is open.
+type ProjectCircuitBreaker struct {
+	// SampleRate is how often, in seconds, delivery results are sampled.
+	SampleRate int64 `json:"sample_rate"`
+	// ErrorTimeout is how long, in seconds, the breaker stays open.
+	ErrorTimeout int64 `json:"error_timeout"`
+	// FailureThreshold is the failure percentage that trips the breaker.
+	FailureThreshold int64 `json:"failure_threshold"`
+	// SuccessThreshold is the success percentage that closes it again.
+	SuccessThreshold int64 `json:"success_threshold"`
+	// ObservabilityWindow is the sampling window in minutes.
+	ObservabilityWindow         int64 `json:"observability_window"`
+	MinimumRequestCount         int64 `json:"minimum_request_count"`
+	ConsecutiveFailureThreshold int64 `json:"consecutive_failure_threshold"`
+}
+
+type ProjectRateLimit struct {
+	Count int64 `json:"count"`
+	// Duration is in seconds.
+	Duration int64 `json:"duration"`
+}
+
+type ProjectRetryStrategy struct {
+	// Type is "linear" or "exponential".
+	Type string `json:"type"`
+	// Duration is in seconds.
+	Duration   int64 `json:"duration"`
+	RetryCount int64 `json:"retry_count"`
+}
+
+type ProjectSignature struct {
+	Header   string             `json:"header"`
+	Versions []SignatureVersion `json:"versions"`
+}
+
+type SignatureVersion struct {
+	UID       string    `json:"uid"`
+	Hash      string    `json:"hash"`
+	Encoding  string    `json:"encoding"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetProject is authorised with the personal access token when one is
+// configured, and with the project key otherwise.
+func (we *webhookData) GetProject(projectID string) (*Project, error) {
+	return we.getProject(context.Background(), projectID)
+}
+
+func (we *webhookData) getProject(ctx context.Context, projectID string) (*Project, error) {
+	var project Project
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "GetProject",
+		auth:    personalCredential,
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID),
+		timeout: 2 * time.Second,
+	}, &project)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
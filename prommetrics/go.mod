@@ -0,0 +1,22 @@
+module github.com/formflake/convoy-go/prommetrics
+
+go 1.22.5
+
+require (
+	github.com/formflake/convoy-go v0.0.0
+	github.com/prometheus/client_golang v1.19.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	golang.org/x/sys v0.16.0 // indirect
+	google.golang.org/protobuf v1.32.0 // indirect
+)
+
+// The adapter is developed alongside the SDK; drop this when tagging a
+// release that depends on a published version.
+replace github.com/formflake/convoy-go => ../
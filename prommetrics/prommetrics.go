@@ -0,0 +1,61 @@
+// Package prommetrics adapts convoy.Metrics to Prometheus collectors.
+package prommetrics
+
+import (
+	"time"
+
+	convoy "github.com/formflake/convoy-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Metrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+var _ convoy.Metrics = &Metrics{}
+
+// New registers the convoy client collectors with reg and returns a
+// convoy.Metrics that updates them, labelled by operation.
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "convoy_client_requests_total",
+			Help: "Requests sent to Convoy.",
+		}, []string{"op"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "convoy_client_errors_total",
+			Help: "Requests to Convoy that failed.",
+		}, []string{"op"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "convoy_client_retries_total",
+			Help: "Requests to Convoy that were retried.",
+		}, []string{"op"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "convoy_client_request_duration_seconds",
+			Help:    "Latency of requests to Convoy.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"op"}),
+	}
+	reg.MustRegister(m.requests, m.errors, m.retries, m.latency)
+
+	return m
+}
+
+func (m *Metrics) IncRequest(op string) {
+	m.requests.WithLabelValues(op).Inc()
+}
+
+func (m *Metrics) IncError(op string) {
+	m.errors.WithLabelValues(op).Inc()
+}
+
+func (m *Metrics) IncRetry(op string) {
+	m.retries.WithLabelValues(op).Inc()
+}
+
+func (m *Metrics) ObserveLatency(op string, d time.Duration) {
+	m.latency.WithLabelValues(op).Observe(d.Seconds())
+}
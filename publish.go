@@ -0,0 +1,40 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+)
+
+// Publish sends an event of eventType carrying data to every endpoint owned
+// by ownerID in the client's default project, and returns the ID of the
+// created event. It is the simplest way to emit events: Convoy fans the event
+// out to the owner's endpoints according to their subscriptions.
+func (we *webhookData) Publish(ctx context.Context, ownerID, eventType string, data any) (string, error) {
+	body, err := we.marshalEvent(struct {
+		OwnerID   string `json:"owner_id"`
+		EventType string `json:"event_type"`
+		Data      any    `json:"data"`
+	}{ownerID, eventType, data})
+	if err != nil {
+		return "", err
+	}
+	if err := we.checkPayloadSize(len(body)); err != nil {
+		return "", err
+	}
+
+	var event EventResponse
+	err = we.do(request{
+		ctx:       ctx,
+		op:        "Publish",
+		method:    http.MethodPost,
+		path:      we.projectPath("", "/events/fanout"),
+		header:    we.eventHeaders,
+		body:      body,
+		onAttempt: we.eventAttempt,
+	}, &event)
+	if err != nil {
+		return "", err
+	}
+
+	return event.Data.UID, nil
+}
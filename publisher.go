@@ -0,0 +1,126 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPublisherClosed is returned by PublishAsync after Drain.
+var ErrPublisherClosed = errors.New("convoy: publisher closed")
+
+// PublisherOptions configures StartPublisher. Zero or negative values select
+// defaults.
+type PublisherOptions struct {
+	// ProjectID receives the events. Defaults to the client's default project.
+	ProjectID string
+	// Workers is the number of concurrent CreateEvent calls. Defaults to
+	// bulkConcurrency.
+	Workers int
+	// QueueSize bounds the events waiting for a worker; PublishAsync blocks
+	// while the queue is full. Defaults to 100.
+	QueueSize int
+}
+
+// PublishResult reports the outcome of one event passed to PublishAsync.
+type PublishResult struct {
+	Webhook *Webhook
+	// EventID is the ID of the created event, empty when Err is set.
+	EventID string
+	Err     error
+}
+
+// Publisher creates events from a queue with a pool of workers. It is safe
+// for concurrent use.
+type Publisher struct {
+	we        *webhookData
+	ctx       context.Context
+	projectID string
+	queue     chan *Webhook
+	results   chan PublishResult
+	wg        sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// StartPublisher starts a Publisher whose workers run until Drain is called.
+// Cancelling ctx fails the events still queued. Every event passed to
+// PublishAsync produces one PublishResult, so Results must be read until it
+// is closed or the workers stall.
+func (we *webhookData) StartPublisher(ctx context.Context, opts PublisherOptions) *Publisher {
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = bulkConcurrency
+	}
+
+	p := &Publisher{
+		we:        we,
+		ctx:       ctx,
+		projectID: opts.ProjectID,
+		queue:     make(chan *Webhook, queueSize),
+		results:   make(chan PublishResult, queueSize),
+	}
+	for range workers {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+func (p *Publisher) work() {
+	defer p.wg.Done()
+	for webhook := range p.queue {
+		result := PublishResult{Webhook: webhook}
+		event, err := p.we.createEvent(p.ctx, p.projectID, webhook)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.EventID = event.Data.UID
+		}
+		p.results <- result
+	}
+}
+
+// PublishAsync queues webhook for creation, blocking while the queue is full
+// until ctx ends. The outcome is delivered on Results.
+func (p *Publisher) PublishAsync(ctx context.Context, webhook *Webhook) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPublisherClosed
+	}
+
+	select {
+	case p.queue <- webhook:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Results returns the channel of publish outcomes. It is closed once Drain
+// has finished.
+func (p *Publisher) Results() <-chan PublishResult {
+	return p.results
+}
+
+// Drain stops accepting events, waits for the queued ones to be created and
+// then closes Results. It is safe to call more than once.
+func (p *Publisher) Drain() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	close(p.results)
+}
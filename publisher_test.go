@@ -0,0 +1,34 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStartPublisherNegativeOptions(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusCreated, `{"status":true,"data":{"uid":"ev-1"}}`))
+	we := client.WebhookInterface.(*webhookData)
+	p := we.StartPublisher(context.Background(), PublisherOptions{Workers: -1, QueueSize: -5})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for range 3 {
+		if err := p.PublishAsync(ctx, &Webhook{Data: WebhookData{EndpointID: "ep-1", EventType: "invoice.paid"}}); err != nil {
+			t.Fatalf("PublishAsync: %v", err)
+		}
+	}
+	p.Drain()
+
+	n := 0
+	for result := range p.Results() {
+		if result.Err != nil || result.EventID != "ev-1" {
+			t.Errorf("result = %+v, want event ev-1", result)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("got %d results, want 3", n)
+	}
+}
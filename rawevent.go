@@ -0,0 +1,65 @@
+package convoy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// PayloadContentTypeHeader carries the original content type of an event
+// created by CreateRawEvent with a non-JSON body.
+const PayloadContentTypeHeader = "X-Payload-Content-Type"
+
+// RawEventOptions are the event fields of CreateRawEvent other than its
+// payload.
+type RawEventOptions struct {
+	EventType      string
+	EndpointID     string
+	IdempotencyKey string
+	// CustomHeaders are forwarded to the endpoint with the event.
+	CustomHeaders map[string]string
+	// Headers are sent to Convoy with the request, as Webhook.Headers.
+	Headers http.Header
+}
+
+// CreateRawEvent creates an event from an already encoded body.
+//
+// Convoy stores event data as JSON and always delivers it as
+// application/json. A JSON body (an empty contentType counts as JSON) is
+// sent as the event data unchanged and must be valid. Any other body, such
+// as protobuf or a form, is sent as a JSON string holding its base64
+// encoding, and contentType is forwarded in the PayloadContentTypeHeader
+// header. Receivers decode the string themselves.
+//
+// Convoy signs the data as delivered, so for non-JSON bodies the signature
+// covers the quoted base64 string, not the original bytes: verify the
+// request body before decoding it.
+func (we *webhookData) CreateRawEvent(projectID string, body []byte, contentType string, opts RawEventOptions) (*EventResponse, error) {
+	webhook := &Webhook{
+		Data: WebhookData{
+			EventType:      opts.EventType,
+			EndpointID:     opts.EndpointID,
+			IdempotencyKey: opts.IdempotencyKey,
+			CustomHeaders:  opts.CustomHeaders,
+		},
+		Headers: opts.Headers,
+	}
+
+	if isJSON(contentType) {
+		if !json.Valid(body) {
+			return nil, fmt.Errorf("convoy: body is not valid JSON for content type %q", contentType)
+		}
+		webhook.Data.Data = json.RawMessage(body)
+	} else {
+		webhook.Data.Data = body
+		webhook.Data.CustomHeaders = maps.Clone(opts.CustomHeaders)
+		if webhook.Data.CustomHeaders == nil {
+			webhook.Data.CustomHeaders = make(map[string]string, 1)
+		}
+		webhook.Data.CustomHeaders[PayloadContentTypeHeader] = contentType
+	}
+
+	return we.createEvent(context.Background(), projectID, webhook)
+}
@@ -0,0 +1,79 @@
+package convoy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/formflake/convoy-go/internal/sensitive"
+)
+
+// DefaultMaxLogBodyBytes caps the request and response bodies written to the
+// logger.
+const DefaultMaxLogBodyBytes = 4 << 10
+
+const redacted = "[REDACTED]"
+
+var bearerToken = regexp.MustCompile(`(?i)bearer\s+[^\s",]+`)
+
+// redactHeader returns a copy of header safe to log.
+func redactHeader(header http.Header) http.Header {
+	safe := header.Clone()
+	for name := range safe {
+		if sensitive.Field(name) || strings.EqualFold(name, "Idempotency-Key") {
+			safe[name] = []string{redacted}
+		}
+	}
+	return safe
+}
+
+// logBody returns body for logging, with sensitive JSON fields and bearer
+// tokens redacted and the result capped at the configured size.
+func (we *webhookData) logBody(body []byte) string {
+	var value any
+	if json.Unmarshal(body, &value) == nil {
+		if encoded, err := json.Marshal(redactValue(value)); err == nil {
+			body = encoded
+		}
+	}
+	text := bearerToken.ReplaceAllString(string(body), "Bearer "+redacted)
+
+	if we.maxLogBody > 0 && len(text) > we.maxLogBody {
+		kept := truncate(text, we.maxLogBody)
+		return fmt.Sprintf("%s... (%d bytes truncated)", kept, len(text)-len(kept))
+	}
+	return text
+}
+
+// truncate returns the longest prefix of s of at most n bytes that doesn't
+// split a rune.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitive.Field(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package convoy
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLogsNeverContainToken(t *testing.T) {
+	const token = "tok_live_0123456789"
+	secrets := []string{token, "whsec_endpoint", "hdr_value_1"}
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	response := `{"status":true,"data":{"uid":"ev-1","echo":"Bearer ` + token + `","secret":"whsec_endpoint"}}`
+	client := newStubClient(t, stubResponse(http.StatusCreated, response),
+		WithProjectKey(token), WithLogger(logger), WithResponseDebugLog(true))
+
+	event := &Webhook{
+		Data: WebhookData{
+			EndpointID: "ep-1",
+			EventType:  "endpoint.created",
+			Data: map[string]any{
+				"secret":         "whsec_endpoint",
+				"authentication": map[string]any{"api_key": map[string]any{"header_value": "hdr_value_1"}},
+				"note":           "sent with Authorization: Bearer " + token,
+			},
+		},
+		Headers: map[string][]string{"Authorization": {"Bearer " + token}},
+	}
+	if err := client.CreateEvent("", event); err != nil {
+		t.Fatal(err)
+	}
+	dryRun, err := client.Clone(WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dryRun.CreateEvent("", event); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, msg := range []string{"created event", "dry run"} {
+		if !strings.Contains(logs.String(), msg) {
+			t.Fatalf("no %q entry logged:\n%s", msg, logs.String())
+		}
+	}
+	for _, secret := range secrets {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs.String())
+		}
+	}
+}
+
+func TestLogBodyTruncatesOnRuneBoundary(t *testing.T) {
+	we := &webhookData{maxLogBody: 5}
+	got := we.logBody([]byte("abcd€€")) // € is 3 bytes; the limit falls inside the first
+	if !utf8.ValidString(got) {
+		t.Fatalf("logBody = %q, not valid UTF-8", got)
+	}
+	if want := "abcd... (6 bytes truncated)"; got != want {
+		t.Errorf("logBody = %q, want %q", got, want)
+	}
+}
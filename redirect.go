@@ -0,0 +1,44 @@
+package convoy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrUnsafeRedirect is returned when following a redirect would change the
+// request method or drop its body.
+var ErrUnsafeRedirect = errors.New("convoy: redirect would not preserve method and body")
+
+type RedirectPolicy int
+
+const (
+	// RedirectPreserve follows redirects only when the method and body are
+	// preserved (307 and 308, or any redirect of a GET). Other redirects
+	// fail with ErrUnsafeRedirect. This is the default.
+	RedirectPreserve RedirectPolicy = iota
+	// RedirectError never follows redirects; a 3xx response is returned as
+	// an error.
+	RedirectError
+	// RedirectFollow follows redirects the way net/http does by default,
+	// which turns a POST into a GET on 301, 302 and 303.
+	RedirectFollow
+)
+
+const maxRedirects = 10
+
+func (p RedirectPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	switch p {
+	case RedirectError:
+		return http.ErrUseLastResponse
+	case RedirectPreserve:
+		if prev := via[len(via)-1]; req.Method != prev.Method {
+			return fmt.Errorf("%w: %s %s redirected to %s %s",
+				ErrUnsafeRedirect, prev.Method, prev.URL, req.Method, req.URL)
+		}
+	}
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return nil
+}
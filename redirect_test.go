@@ -0,0 +1,67 @@
+package convoy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateEventRedirect(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		policy     RedirectPolicy
+		status     int
+		wantErr    error
+		wantMethod string // of the request reaching the new location
+	}{
+		{"preserve 307", RedirectPreserve, http.StatusTemporaryRedirect, nil, http.MethodPost},
+		{"preserve 308", RedirectPreserve, http.StatusPermanentRedirect, nil, http.MethodPost},
+		{"preserve 302", RedirectPreserve, http.StatusFound, ErrUnsafeRedirect, ""},
+		{"error 307", RedirectError, http.StatusTemporaryRedirect, &APIError{}, ""},
+		{"follow 302", RedirectFollow, http.StatusFound, nil, http.MethodGet},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var method, body string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/projects/project/events", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/moved/api/v1/projects/project/events", c.status)
+			})
+			mux.HandleFunc("/moved/", func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				method, body = r.Method, string(b)
+				w.WriteHeader(http.StatusCreated)
+				io.WriteString(w, `{"status":true,"data":{"uid":"ev-1"}}`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+			client, err := NewWebhook(srv.URL, "key", "project", WithRedirectPolicy(c.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = client.CreateEvent("", &Webhook{Data: WebhookData{EndpointID: "ep-1", EventType: "invoice.paid", Data: map[string]any{"id": 1}}})
+			switch want := c.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatal(err)
+				}
+			case *APIError:
+				if !errors.As(err, &want) || want.StatusCode != c.status {
+					t.Fatalf("err = %v, want a %d APIError", err, c.status)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("err = %v, want %v", err, want)
+				}
+			}
+			if method != c.wantMethod {
+				t.Errorf("redirect target got %q, want %q", method, c.wantMethod)
+			}
+			if method == http.MethodPost && body == "" {
+				t.Error("redirected POST lost its body")
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package convoy
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+func (we *webhookData) RetryEventDelivery(projectID, deliveryID string) error {
+	return we.retryEventDelivery(context.Background(), projectID, deliveryID)
+}
+
+func (we *webhookData) retryEventDelivery(ctx context.Context, projectID, deliveryID string) error {
+	return we.do(request{
+		ctx:     ctx,
+		op:      "RetryEventDelivery",
+		method:  http.MethodPut,
+		path:    we.projectPath(projectID, "/eventdeliveries/", deliveryID, "/resend"),
+		timeout: 2 * time.Second,
+	}, nil)
+}
+
+// ReplayQuery selects the deliveries ReplayEvents retries. Leave EndpointID
+// empty to replay across the project and set StartDate and EndDate to the
+// outage window. Status defaults to failed and discarded deliveries. Set
+// NextPageCursor to a ReplayProgress.Cursor to resume an interrupted replay.
+type ReplayQuery struct {
+	DeliveryQuery
+
+	// Concurrency bounds the retries in flight. Defaults to 4.
+	Concurrency int
+	// Progress, if set, is called after each page of deliveries.
+	Progress func(ReplayProgress)
+}
+
+type ReplayProgress struct {
+	Retried int
+	// Failed maps delivery IDs that could not be retried to the error.
+	Failed map[string]error
+	// Cursor resumes the replay after the last completed page. It is empty
+	// once every page has been processed.
+	Cursor string
+}
+
+// ReplayEvents retries every delivery matching query, one page at a time.
+// It returns the progress made so far along with any error that stopped the
+// replay; failures of individual deliveries are reported in Failed instead.
+func (we *webhookData) ReplayEvents(ctx context.Context, projectID string, query ReplayQuery) (*ReplayProgress, error) {
+	if len(query.Status) == 0 {
+		query.Status = []DeliveryStatus{DeliveryStatusFailure, DeliveryStatusDiscarded}
+	}
+	concurrency := query.Concurrency
+	if concurrency <= 0 {
+		concurrency = bulkConcurrency
+	}
+
+	progress := &ReplayProgress{
+		Failed: make(map[string]error),
+		Cursor: query.NextPageCursor,
+	}
+	var mu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+
+	for {
+		page, err := we.listEventDeliveries(ctx, projectID, query.DeliveryQuery)
+		if err != nil {
+			return progress, err
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range page.Data.Content {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				wg.Wait()
+				return progress, ctx.Err()
+			}
+			wg.Add(1)
+			go func(deliveryID string) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				err := we.retryEventDelivery(ctx, projectID, deliveryID)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					progress.Failed[deliveryID] = err
+					return
+				}
+				progress.Retried++
+			}(delivery.UID)
+		}
+		wg.Wait()
+
+		pagination := page.Data.Pagination
+		progress.Cursor = ""
+		if pagination.HasNextPage {
+			progress.Cursor = pagination.NextPageCursor
+		}
+		if query.Progress != nil {
+			query.Progress(*progress)
+		}
+		if progress.Cursor == "" {
+			return progress, nil
+		}
+		query.NextPageCursor = progress.Cursor
+		query.PrevPageCursor = ""
+	}
+}
+
+// MaxRetryableDeliveries bounds the deliveries ListRetryableDeliveries
+// returns.
+const MaxRetryableDeliveries = 1000
+
+// ListRetryableDeliveries returns the failed and discarded deliveries of the
+// project created within the last duration, newest first, following
+// pagination up to MaxRetryableDeliveries. Retry them with
+// RetryEventDelivery, or use ReplayEvents for larger windows.
+func (we *webhookData) ListRetryableDeliveries(projectID string, within time.Duration) ([]EventDeliveryContent, error) {
+	query := DeliveryQuery{
+		Status:    []DeliveryStatus{DeliveryStatusFailure, DeliveryStatusDiscarded},
+		StartDate: we.clock.Now().Add(-within),
+		PerPage:   100,
+	}
+
+	var deliveries []EventDeliveryContent
+	for len(deliveries) < MaxRetryableDeliveries {
+		page, err := we.listEventDeliveries(context.Background(), projectID, query)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, page.Data.Content...)
+
+		if !page.Data.Pagination.HasNextPage {
+			break
+		}
+		query.NextPageCursor = page.Data.Pagination.NextPageCursor
+	}
+
+	if len(deliveries) > MaxRetryableDeliveries {
+		deliveries = deliveries[:MaxRetryableDeliveries]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,55 @@
+package convoy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// retryable reports whether a request may be sent again after the outcome
+// of its attempt'th attempt. Only GET requests, requests carrying an
+// idempotency key and idempotent operations are retried, and by default only
+// after a transport error, a 429 or a 5xx response; a decider set with
+// WithRetryDecider replaces that last part.
+func (we *webhookData) retryable(r request, resp *http.Response, err error, attempt int) bool {
+	if r.method != http.MethodGet && r.idempotencyKey == "" && !r.idempotent || r.stream != nil {
+		return false
+	}
+	if we.retryDecider != nil {
+		return we.retryDecider(resp, err, attempt)
+	}
+	if err != nil {
+		return r.ctx.Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// RetryError is returned when a request failed on every attempt. Unwrap
+// returns the error of the last attempt.
+type RetryError struct {
+	Attempts int
+	// Elapsed is the time from the first attempt to giving up.
+	Elapsed time.Duration
+	// Errors holds the error of each attempt, oldest first.
+	Errors []error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts over %s: %v",
+		e.Attempts, e.Elapsed.Round(time.Millisecond), e.Unwrap())
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Errors[len(e.Errors)-1]
+}
+
+// LastStatusCode returns the status code of the last attempt, or 0 if it
+// failed without a response.
+func (e *RetryError) LastStatusCode() int {
+	var apiErr *APIError
+	if errors.As(e.Unwrap(), &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package convoy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type EventList struct {
+	Message string          `json:"message"`
+	Status  bool            `json:"status"`
+	Data    Page[EventData] `json:"data"`
+}
+
+// SearchQuery selects events for SearchEvents. Zero-valued fields are not
+// sent.
+type SearchQuery struct {
+	// Query is matched against the event payloads. It needs search to be
+	// enabled on the server.
+	Query       string
+	EndpointIDs []string
+	SourceIDs   []string
+	// IdempotencyKey finds the event created with that key.
+	IdempotencyKey string
+	StartDate      time.Time
+	EndDate        time.Time
+
+	PerPage        int64
+	NextPageCursor string
+	PrevPageCursor string
+}
+
+func (q SearchQuery) values() url.Values {
+	query := url.Values{}
+	if q.Query != "" {
+		query.Set("query", q.Query)
+	}
+	for _, id := range q.EndpointIDs {
+		query.Add("endpointId", id)
+	}
+	for _, id := range q.SourceIDs {
+		query.Add("sourceId", id)
+	}
+	if q.IdempotencyKey != "" {
+		query.Set("idempotencyKey", q.IdempotencyKey)
+	}
+	if !q.StartDate.IsZero() {
+		query.Set("startDate", q.StartDate.UTC().Format(queryTimeFormat))
+	}
+	if !q.EndDate.IsZero() {
+		query.Set("endDate", q.EndDate.UTC().Format(queryTimeFormat))
+	}
+	setPageParams(query, q.PerPage, q.NextPageCursor, q.PrevPageCursor)
+	return query
+}
+
+// SearchEvents returns a page of the events matching query, newest first.
+// When query.Query is set and the server has no search support, the error
+// wraps ErrUnsupportedByServer.
+func (we *webhookData) SearchEvents(projectID string, query SearchQuery) (*EventList, error) {
+	var events EventList
+	err := we.do(request{
+		op:      "SearchEvents",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/events"),
+		query:   query.values(),
+		timeout: 5 * time.Second,
+	}, &events)
+	if err != nil {
+		var apiErr *APIError
+		if query.Query != "" && errors.As(err, &apiErr) && searchUnsupported(apiErr) {
+			return nil, fmt.Errorf("%w: event search: %w", ErrUnsupportedByServer, err)
+		}
+		return nil, err
+	}
+
+	return &events, nil
+}
+
+// searchUnsupported reports whether a failed search was rejected because
+// the server can't search rather than because of the query.
+func searchUnsupported(err *APIError) bool {
+	switch err.StatusCode {
+	case http.StatusNotImplemented:
+		return true
+	case http.StatusBadRequest:
+		return strings.Contains(strings.ToLower(err.Message), "search")
+	}
+	return false
+}
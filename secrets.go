@@ -0,0 +1,127 @@
+package convoy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type EndpointSecret struct {
+	UID       string     `json:"uid"`
+	Value     string     `json:"value"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// RotateEndpointSecret replaces the endpoint's signing secret with newSecret,
+// or with one generated by Convoy when newSecret is empty. The current secret
+// stays valid for expiration, which Convoy counts in whole hours.
+func (we *webhookData) RotateEndpointSecret(projectID, endpointID, newSecret string, expiration time.Duration) (*EndpointResponse, error) {
+	return we.rotateEndpointSecret(context.Background(), projectID, endpointID, newSecret, expiration)
+}
+
+func (we *webhookData) rotateEndpointSecret(ctx context.Context, projectID, endpointID, newSecret string, expiration time.Duration) (*EndpointResponse, error) {
+	body, err := json.Marshal(struct {
+		Secret     string `json:"secret,omitempty"`
+		Expiration int    `json:"expiration"`
+	}{newSecret, int(expiration.Hours())})
+	if err != nil {
+		return nil, err
+	}
+
+	var response EndpointResponse
+	err = we.do(request{
+		ctx:     ctx,
+		op:      "RotateEndpointSecret",
+		method:  http.MethodPut,
+		path:    we.projectPath(projectID, "/endpoints/", endpointID, "/expire_secret"),
+		body:    body,
+		timeout: 2 * time.Second,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Steps of RotateAndTestSecret reported in SecretRotationError.
+const (
+	RotationStepFetch    = "fetch"
+	RotationStepRotate   = "rotate"
+	RotationStepTest     = "test"
+	RotationStepRollback = "rollback"
+)
+
+// SecretRotationError reports which step of RotateAndTestSecret failed and
+// whether the previous secret was restored.
+type SecretRotationError struct {
+	Step       string
+	RolledBack bool
+	Err        error
+}
+
+func (e *SecretRotationError) Error() string {
+	msg := fmt.Sprintf("secret rotation failed at %s step: %v", e.Step, e.Err)
+	if e.Step == RotationStepTest && e.RolledBack {
+		msg += " (previous secret restored)"
+	}
+	return msg
+}
+
+func (e *SecretRotationError) Unwrap() error {
+	return e.Err
+}
+
+const secretTestTimeout = 30 * time.Second
+
+// RotateAndTestSecret rotates the endpoint's secret, expiring the current
+// one immediately, then sends a TestEventType event and waits for it to be
+// delivered. If the delivery doesn't succeed the previous secret is put back.
+// Failures are returned as *SecretRotationError.
+func (we *webhookData) RotateAndTestSecret(projectID, endpointID string) error {
+	ctx := context.Background()
+
+	endpoint, err := we.GetEndpoint(projectID, endpointID)
+	if err != nil {
+		return &SecretRotationError{Step: RotationStepFetch, Err: err}
+	}
+	oldSecret := currentSecret(endpoint.Data.Secrets)
+	if oldSecret == "" {
+		return &SecretRotationError{Step: RotationStepFetch, Err: errors.New("endpoint has no active secret")}
+	}
+
+	if _, err := we.rotateEndpointSecret(ctx, projectID, endpointID, "", 0); err != nil {
+		return &SecretRotationError{Step: RotationStepRotate, Err: err}
+	}
+
+	eventID, err := we.sendTestEvent(ctx, projectID, endpointID)
+	if err == nil {
+		err = we.WaitForDeliverySuccess(ctx, projectID, endpointID, eventID, secretTestTimeout)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if _, rollbackErr := we.rotateEndpointSecret(ctx, projectID, endpointID, oldSecret, 0); rollbackErr != nil {
+		return &SecretRotationError{
+			Step: RotationStepRollback,
+			Err:  fmt.Errorf("restoring previous secret after failed test (%v): %w", err, rollbackErr),
+		}
+	}
+	return &SecretRotationError{Step: RotationStepTest, RolledBack: true, Err: err}
+}
+
+// currentSecret returns the newest secret that hasn't been given an expiry.
+func currentSecret(secrets []EndpointSecret) string {
+	for i := len(secrets) - 1; i >= 0; i-- {
+		if secrets[i].ExpiresAt == nil {
+			return secrets[i].Value
+		}
+	}
+	return ""
+}
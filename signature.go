@@ -0,0 +1,242 @@
+package convoy
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("convoy: invalid signature")
+	ErrSignatureExpired = errors.New("convoy: signature timestamp outside tolerance")
+	// ErrSignatureReplayed is returned when an advanced signature was
+	// already accepted, see SignatureOptions.Seen.
+	ErrSignatureReplayed = errors.New("convoy: signature already seen")
+)
+
+type SignatureEncoding int
+
+const (
+	// EncodingHex is the default encoding of simple signatures.
+	EncodingHex SignatureEncoding = iota
+	// EncodingBase64 is the default encoding of advanced signatures.
+	EncodingBase64
+	// EncodingAuto accepts a signature in either hex or base64. Both forms
+	// carry the same HMAC and each candidate is compared in constant time,
+	// so this doesn't make forgery easier, but it does hide a sender
+	// misconfiguration. Prefer the explicit encoding of the project.
+	EncodingAuto
+)
+
+const defaultSignatureTolerance = 5 * time.Minute
+
+// DefaultSignatureHeader is the header Convoy sends signatures in unless the
+// project configures another one.
+const DefaultSignatureHeader = "X-Convoy-Signature"
+
+type SignatureOptions struct {
+	// Hash is the HMAC hash function. Defaults to sha256.New.
+	Hash func() hash.Hash
+	// Encoding of the signature. ComputeSignature treats EncodingAuto as
+	// EncodingHex.
+	Encoding SignatureEncoding
+	// Tolerance bounds the age of an advanced signature's timestamp.
+	// Defaults to five minutes.
+	Tolerance time.Duration
+	// Header is the name of the signature header read by VerifyRequest.
+	// Defaults to DefaultSignatureHeader.
+	Header string
+	// Seen, when set, records accepted advanced signatures and rejects
+	// repeats within the tolerance window with ErrSignatureReplayed.
+	// Simple signatures carry no timestamp and are never recorded.
+	Seen SeenCache
+}
+
+// SeenCache remembers signatures accepted by VerifySignature, for replay
+// protection across the process or, with a shared store, across instances.
+type SeenCache interface {
+	// AddIfAbsent records key, which may be forgotten after ttl, and
+	// reports whether it was absent. It must be atomic, so that of
+	// concurrent calls with the same key only one reports true.
+	AddIfAbsent(key string, ttl time.Duration) bool
+}
+
+// MemorySeenCache is an in-process SeenCache. The zero value is ready to
+// use and it is safe for concurrent use. Expired keys are swept at most once
+// per ttl, so adding stays cheap however many keys are held.
+type MemorySeenCache struct {
+	mu        sync.Mutex
+	expires   map[string]time.Time
+	nextSweep time.Time
+}
+
+func (c *MemorySeenCache) AddIfAbsent(key string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.expires == nil {
+		c.expires = make(map[string]time.Time)
+	}
+	if expiry, ok := c.expires[key]; ok && now.Before(expiry) {
+		return false
+	}
+	if !now.Before(c.nextSweep) {
+		for k, expiry := range c.expires {
+			if !now.Before(expiry) {
+				delete(c.expires, k)
+			}
+		}
+		c.nextSweep = now.Add(ttl)
+	}
+	c.expires[key] = now.Add(ttl)
+	return true
+}
+
+func (o SignatureOptions) mac(secret string, payload []byte) []byte {
+	newHash := o.Hash
+	if newHash == nil {
+		newHash = sha256.New
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// ComputeSignature returns the simple signature of payload as Convoy sends
+// it in the signature header.
+func ComputeSignature(secret string, payload []byte, opts SignatureOptions) string {
+	sum := opts.mac(secret, payload)
+	if opts.Encoding == EncodingBase64 {
+		return base64.StdEncoding.EncodeToString(sum)
+	}
+	return hex.EncodeToString(sum)
+}
+
+// SigningString returns the bytes Convoy signs for an advanced signature
+// with timestamp ts: the decimal Unix timestamp, a comma, then the payload
+// exactly as delivered, such as "1700000000,{\"id\":1}". The HMAC of this
+// string is what each v1 value encodes. Simple signatures sign the payload
+// alone.
+func SigningString(ts int64, payload []byte) []byte {
+	s := strconv.AppendInt(make([]byte, 0, 21+len(payload)), ts, 10)
+	s = append(s, ',')
+	return append(s, payload...)
+}
+
+// VerifySignature checks header, the value of the signature header of a
+// Convoy delivery, against payload. Both simple signatures and advanced
+// ones of the form "t=<unix>,v1=<sig>[,v1=<sig>...]" are accepted; for the
+// latter the timestamp must be within opts.Tolerance and any v1 value may
+// match, which covers secrets being rotated.
+func VerifySignature(secret string, payload []byte, header string, opts SignatureOptions) error {
+	if !strings.HasPrefix(header, "t=") {
+		if signatureMatches(opts.mac(secret, payload), header, opts.Encoding) {
+			return nil
+		}
+		return ErrInvalidSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad timestamp %q", ErrInvalidSignature, timestamp)
+	}
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = defaultSignatureTolerance
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	sum := opts.mac(secret, SigningString(ts, payload))
+	for _, signature := range signatures {
+		if !signatureMatches(sum, signature, opts.Encoding) {
+			continue
+		}
+		if opts.Seen != nil {
+			// Past twice the tolerance the timestamp check rejects it.
+			if !opts.Seen.AddIfAbsent(fmt.Sprintf("%d,%s", ts, signature), 2*tolerance) {
+				return ErrSignatureReplayed
+			}
+		}
+		return nil
+	}
+	return ErrInvalidSignature
+}
+
+func signatureMatches(sum []byte, signature string, encoding SignatureEncoding) bool {
+	switch encoding {
+	case EncodingHex:
+		return decodedEqual(sum, signature, hex.DecodeString)
+	case EncodingBase64:
+		return decodedEqual(sum, signature, base64.StdEncoding.DecodeString)
+	case EncodingAuto:
+		// Evaluate both so the time taken doesn't reveal the encoding.
+		isHex := decodedEqual(sum, signature, hex.DecodeString)
+		isBase64 := decodedEqual(sum, signature, base64.StdEncoding.DecodeString)
+		return isHex || isBase64
+	}
+	return false
+}
+
+func decodedEqual(sum []byte, signature string, decode func(string) ([]byte, error)) bool {
+	decoded, err := decode(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(sum, decoded)
+}
+
+// SignatureFromRequest returns the signature sent in the named header of r,
+// or in DefaultSignatureHeader when header is empty.
+func SignatureFromRequest(r *http.Request, header string) string {
+	if header == "" {
+		header = DefaultSignatureHeader
+	}
+	return r.Header.Get(header)
+}
+
+// VerifyRequest reads the body of an inbound Convoy delivery and verifies it
+// against the signature in the opts.Header header. It returns the body, and
+// leaves r.Body readable again for later handlers.
+func VerifyRequest(r *http.Request, secret string, opts SignatureOptions) ([]byte, error) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(payload))
+
+	signature := SignatureFromRequest(r, opts.Header)
+	if signature == "" {
+		return nil, fmt.Errorf("%w: missing %s header", ErrInvalidSignature, cmp.Or(opts.Header, DefaultSignatureHeader))
+	}
+	if err := VerifySignature(secret, payload, signature, opts); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,126 @@
+package convoy
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVerifySignatureReplayedConcurrently(t *testing.T) {
+	const secret = "whsec_1"
+	payload := []byte(`{"id":1}`)
+	ts := time.Now().Unix()
+	header := "t=" + strconv.FormatInt(ts, 10) + ",v1=" +
+		ComputeSignature(secret, SigningString(ts, payload), SignatureOptions{Encoding: EncodingBase64})
+	opts := SignatureOptions{Encoding: EncodingBase64, Seen: &MemorySeenCache{}}
+
+	var accepted, replayed atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch err := VerifySignature(secret, payload, header, opts); {
+			case err == nil:
+				accepted.Add(1)
+			case errors.Is(err, ErrSignatureReplayed):
+				replayed.Add(1)
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if accepted.Load() != 1 || replayed.Load() != 49 {
+		t.Errorf("accepted %d, replayed %d; want 1 and 49", accepted.Load(), replayed.Load())
+	}
+}
+
+func TestMemorySeenCacheExpiry(t *testing.T) {
+	var c MemorySeenCache
+	if !c.AddIfAbsent("a", time.Millisecond) || c.AddIfAbsent("a", time.Millisecond) {
+		t.Fatal("second add of a fresh key reported it absent")
+	}
+	time.Sleep(2 * time.Millisecond)
+	if !c.AddIfAbsent("a", time.Hour) {
+		t.Error("expired key reported present")
+	}
+
+	var swept MemorySeenCache
+	for i := range 1000 {
+		swept.AddIfAbsent(fmt.Sprint("k", i), time.Millisecond)
+	}
+	time.Sleep(2 * time.Millisecond)
+	swept.AddIfAbsent("last", time.Millisecond)
+	if n := len(swept.expires); n != 1 {
+		t.Errorf("held %d keys after they expired, want only the last", n)
+	}
+}
+
+func TestVerifySignatureEncodings(t *testing.T) {
+	const secret = "whsec_1"
+	payload := []byte(`{"id":1,"amount":100}`)
+	hexSig := ComputeSignature(secret, payload, SignatureOptions{Encoding: EncodingHex})
+	b64Sig := ComputeSignature(secret, payload, SignatureOptions{Encoding: EncodingBase64})
+	ts := time.Now().Unix()
+	advanced := func(encoding SignatureEncoding) string {
+		return "t=" + strconv.FormatInt(ts, 10) + ",v1=" +
+			ComputeSignature(secret, SigningString(ts, payload), SignatureOptions{Encoding: encoding})
+	}
+
+	for _, c := range []struct {
+		name     string
+		secret   string
+		payload  []byte
+		header   string
+		encoding SignatureEncoding
+		wantErr  error
+	}{
+		{"hex", secret, payload, hexSig, EncodingHex, nil},
+		{"base64", secret, payload, b64Sig, EncodingBase64, nil},
+		{"auto hex", secret, payload, hexSig, EncodingAuto, nil},
+		{"auto base64", secret, payload, b64Sig, EncodingAuto, nil},
+		{"advanced hex", secret, payload, advanced(EncodingHex), EncodingHex, nil},
+		{"advanced base64", secret, payload, advanced(EncodingBase64), EncodingBase64, nil},
+		{"advanced auto", secret, payload, advanced(EncodingHex), EncodingAuto, nil},
+		{"base64 under hex", secret, payload, b64Sig, EncodingHex, ErrInvalidSignature},
+		{"hex under base64", secret, payload, hexSig, EncodingBase64, ErrInvalidSignature},
+		{"advanced base64 under hex", secret, payload, advanced(EncodingBase64), EncodingHex, ErrInvalidSignature},
+		{"tampered payload", secret, []byte(`{"id":1,"amount":900}`), hexSig, EncodingHex, ErrInvalidSignature},
+		{"tampered payload auto", secret, []byte(`{"id":1,"amount":900}`), b64Sig, EncodingAuto, ErrInvalidSignature},
+		{"tampered advanced payload", secret, []byte(`{"id":2}`), advanced(EncodingBase64), EncodingBase64, ErrInvalidSignature},
+		{"wrong secret", "whsec_2", payload, hexSig, EncodingHex, ErrInvalidSignature},
+		{"wrong secret auto", "whsec_2", payload, b64Sig, EncodingAuto, ErrInvalidSignature},
+		{"wrong secret advanced", "whsec_2", payload, advanced(EncodingBase64), EncodingBase64, ErrInvalidSignature},
+		{"empty", secret, payload, "", EncodingAuto, ErrInvalidSignature},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := VerifySignature(c.secret, c.payload, c.header, SignatureOptions{Encoding: c.encoding})
+			if c.wantErr == nil && err != nil || !errors.Is(err, c.wantErr) {
+				t.Errorf("VerifySignature = %v, want %v", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestComputeSignatureRoundTrip(t *testing.T) {
+	payload := []byte("hello")
+	// HMAC-SHA256("key", "hello").
+	const want = "9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b"
+	if got := ComputeSignature("key", payload, SignatureOptions{}); got != want {
+		t.Errorf("hex signature = %s, want %s", got, want)
+	}
+	if got := ComputeSignature("key", payload, SignatureOptions{Encoding: EncodingAuto}); got != want {
+		t.Errorf("auto signature = %s, want hex %s", got, want)
+	}
+	b64 := ComputeSignature("key", payload, SignatureOptions{Encoding: EncodingBase64})
+	if decoded, err := base64.StdEncoding.DecodeString(b64); err != nil || hex.EncodeToString(decoded) != want {
+		t.Errorf("base64 signature %s decodes to %x, %v; want %s", b64, decoded, err, want)
+	}
+}
@@ -0,0 +1,88 @@
+package convoy
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Source types and providers understood by Convoy.
+const (
+	SourceTypeHTTP   = "http"
+	SourceTypePubSub = "pub_sub"
+
+	SourceProviderGithub  = "github"
+	SourceProviderStripe  = "stripe"
+	SourceProviderShopify = "shopify"
+	SourceProviderTwitter = "twitter"
+)
+
+type SourceList struct {
+	Message string           `json:"message"`
+	Status  bool             `json:"status"`
+	Data    Page[SourceData] `json:"data"`
+}
+
+type SourceData struct {
+	UID        string         `json:"uid"`
+	MaskID     string         `json:"mask_id"`
+	ProjectID  string         `json:"project_id"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Provider   string         `json:"provider"`
+	IsDisabled bool           `json:"is_disabled"`
+	Verifier   SourceVerifier `json:"verifier"`
+	// URL is the ingest URL events for this source are sent to.
+	URL       string     `json:"url"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type SourceVerifier struct {
+	Type string `json:"type"`
+	HMac *struct {
+		Header   string `json:"header"`
+		Hash     string `json:"hash"`
+		Secret   string `json:"secret"`
+		Encoding string `json:"encoding"`
+	} `json:"hmac"`
+	BasicAuth *struct {
+		UserName string `json:"username"`
+		Password string `json:"password"`
+	} `json:"basic_auth"`
+	APIKey *struct {
+		HeaderName  string `json:"header_name"`
+		HeaderValue string `json:"header_value"`
+	} `json:"api_key"`
+}
+
+// SourceQuery filters ListSources. Zero-valued fields are not sent.
+type SourceQuery struct {
+	Type     string
+	Provider string
+
+	PerPage        int64
+	NextPageCursor string
+	PrevPageCursor string
+}
+
+func (q SourceQuery) values() url.Values {
+	query := url.Values{}
+	if q.Type != "" {
+		query.Set("type", q.Type)
+	}
+	if q.Provider != "" {
+		query.Set("provider", q.Provider)
+	}
+	setPageParams(query, q.PerPage, q.NextPageCursor, q.PrevPageCursor)
+	return query
+}
+
+func (we *webhookData) ListSources(projectID string, query SourceQuery) (*SourceList, error) {
+	page, err := listPage[SourceData](context.Background(), we, "ListSources", we.projectPath(projectID, "/sources"), query.values())
+	if err != nil {
+		return nil, err
+	}
+	return (*SourceList)(page), nil
+}
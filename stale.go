@@ -0,0 +1,82 @@
+package convoy
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// StaleEndpoints returns the endpoints of the project that have had no
+// delivery since the given time, oldest UpdatedAt first and then by ID.
+// Convoy can't sort or filter endpoints by activity, so every endpoint is
+// listed and its deliveries since then are checked, with bounded
+// concurrency.
+func (we *webhookData) StaleEndpoints(ctx context.Context, projectID string, since time.Time) ([]EndpointData, error) {
+	endpoints, err := we.listAllEndpoints(ctx, projectID, EndpointQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stale := make([]bool, len(endpoints))
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, endpointID string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			deliveries, err := we.listEventDeliveries(ctx, projectID, DeliveryQuery{
+				EndpointID: endpointID,
+				StartDate:  since,
+				PerPage:    1,
+			})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				cancel()
+				return
+			}
+			stale[i] = len(deliveries.Data.Content) == 0
+		}(i, endpoint.UID)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var result []EndpointData
+	for i, endpoint := range endpoints {
+		if stale[i] {
+			result = append(result, endpoint)
+		}
+	}
+	slices.SortFunc(result, func(a, b EndpointData) int {
+		return cmp.Or(a.UpdatedAt.Compare(b.UpdatedAt), cmp.Compare(a.UID, b.UID))
+	})
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package convoy
+
+import (
+	"context"
+	"time"
+)
+
+// ProjectStats summarises a project over a time window.
+type ProjectStats struct {
+	Since time.Time
+	Until time.Time
+
+	Endpoints         int
+	PausedEndpoints   int
+	InactiveEndpoints int
+
+	// Deliveries counts the event deliveries created in the window, and
+	// FailedDeliveries those of them that ended in failure.
+	Deliveries       int64
+	FailedDeliveries int64
+}
+
+// GetProjectStats returns the counts of the project's endpoints and of its
+// deliveries over the last window. Convoy's dashboard statistics are not
+// part of the project API, so they are assembled from the endpoint list and
+// delivery counts; endpoints are listed in full, one request per page.
+func (we *webhookData) GetProjectStats(projectID string, window time.Duration) (*ProjectStats, error) {
+	ctx := context.Background()
+	until := we.clock.Now().UTC()
+	stats := &ProjectStats{Since: until.Add(-window), Until: until}
+
+	endpoints, err := we.listAllEndpoints(ctx, projectID, EndpointQuery{})
+	if err != nil {
+		return nil, err
+	}
+	stats.Endpoints = len(endpoints)
+	for _, endpoint := range endpoints {
+		switch {
+		case endpoint.IsPaused():
+			stats.PausedEndpoints++
+		case endpoint.IsCircuitBroken():
+			stats.InactiveEndpoints++
+		}
+	}
+
+	query := DeliveryQuery{StartDate: stats.Since, EndDate: stats.Until}
+	if stats.Deliveries, err = we.CountEventDeliveries(projectID, query); err != nil {
+		return nil, err
+	}
+	query.Status = []DeliveryStatus{DeliveryStatusFailure}
+	if stats.FailedDeliveries, err = we.CountEventDeliveries(projectID, query); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// SubscriptionStats counts the deliveries to a subscription's endpoint over
+// a time window, by outcome.
+type SubscriptionStats struct {
+	SubscriptionID string
+	EndpointID     string
+	Since          time.Time
+	Until          time.Time
+
+	Succeeded int64
+	Failed    int64
+	Discarded int64
+	// Pending counts deliveries still scheduled, processing or retrying.
+	Pending int64
+}
+
+// GetSubscriptionStats returns the delivery counts of the subscription over
+// the last window. Convoy has no per-subscription statistics and can't
+// count deliveries by subscription, so the counts are those of the
+// subscription's endpoint: they include deliveries routed by any other
+// subscription to the same endpoint.
+func (we *webhookData) GetSubscriptionStats(projectID, subscriptionID string, window time.Duration) (*SubscriptionStats, error) {
+	subscription, err := we.GetSubscription(projectID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	until := we.clock.Now().UTC()
+	stats := &SubscriptionStats{
+		SubscriptionID: subscriptionID,
+		EndpointID:     subscription.Data.EndpointID,
+		Since:          until.Add(-window),
+		Until:          until,
+	}
+	counts := []struct {
+		count    *int64
+		statuses []DeliveryStatus
+	}{
+		{&stats.Succeeded, []DeliveryStatus{DeliveryStatusSuccess}},
+		{&stats.Failed, []DeliveryStatus{DeliveryStatusFailure}},
+		{&stats.Discarded, []DeliveryStatus{DeliveryStatusDiscarded}},
+		{&stats.Pending, []DeliveryStatus{DeliveryStatusScheduled, DeliveryStatusProcessing, DeliveryStatusRetry}},
+	}
+	for _, c := range counts {
+		*c.count, err = we.CountEventDeliveries(projectID, DeliveryQuery{
+			EndpointID: stats.EndpointID,
+			Status:     c.statuses,
+			StartDate:  stats.Since,
+			EndDate:    stats.Until,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,71 @@
+package convoy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// DefaultStreamThreshold is the payload size above which
+// CreateEventFromReader streams the request body.
+const DefaultStreamThreshold = 1 << 20
+
+// CreateEventFromReader creates the event described by meta with the JSON
+// read from payload as its data; meta.Data is ignored.
+//
+// Payloads up to the stream threshold (see WithStreamThreshold) are
+// buffered and sent like CreateEvent, retried when keyed. Larger ones are
+// streamed as they are read, to bound memory. A streamed body can be read
+// only once, so it is never retried, and only its first part is checked
+// against the payload size limit; streaming is therefore only useful with a
+// limit raised above the threshold or disabled. payload is not validated as
+// JSON; Convoy rejects invalid data.
+func (we *webhookData) CreateEventFromReader(ctx context.Context, projectID string, meta WebhookData, payload io.Reader) (*EventResponse, error) {
+	if err := we.checkDeliverAt(meta.DeliverAt); err != nil {
+		return nil, err
+	}
+
+	// WebhookData encodes Data first, so the envelope splits around it.
+	meta.Data = nil
+	envelope, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	prefix := []byte(`{"data":`)
+	suffix, ok := bytes.CutPrefix(envelope, []byte(`{"data":null`))
+	if !ok {
+		return nil, errors.New("convoy: unexpected event envelope")
+	}
+
+	head, err := io.ReadAll(io.LimitReader(payload, int64(we.streamThreshold)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	r := request{
+		ctx:            ctx,
+		op:             "CreateEvent",
+		method:         http.MethodPost,
+		path:           we.projectPath(projectID, "/events"),
+		header:         we.eventHeaders,
+		idempotencyKey: meta.IdempotencyKey,
+		onAttempt:      we.eventAttempt,
+	}
+	if err := we.checkPayloadSize(len(prefix) + len(head) + len(suffix)); err != nil {
+		return nil, err
+	}
+	if len(head) <= we.streamThreshold {
+		r.body = bytes.Join([][]byte{prefix, head, suffix}, nil)
+	} else {
+		r.stream = io.MultiReader(bytes.NewReader(prefix), bytes.NewReader(head), payload, bytes.NewReader(suffix))
+	}
+
+	var event EventResponse
+	if err := we.do(r, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
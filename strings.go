@@ -0,0 +1,30 @@
+package convoy
+
+import (
+	"fmt"
+	"time"
+)
+
+// String summarises the endpoint on one line. Secrets are not included.
+func (e EndpointData) String() string {
+	return fmt.Sprintf("endpoint %s %q (%s) %s owner=%s", e.UID, e.Name, e.Status, e.URL, e.OwnerID)
+}
+
+// String summarises the delivery on one line.
+func (d EventDeliveryContent) String() string {
+	return fmt.Sprintf("delivery %s event=%s type=%s endpoint=%s %s attempts=%d/%d created=%s",
+		d.UID, d.EventID, d.EventMetadata.EventType, d.EndpointID, d.Status,
+		d.Metadata.NumTrials, d.Metadata.RetryLimit, d.CreatedAt.Format(time.RFC3339))
+}
+
+// String summarises the subscription on one line.
+func (s SubscriptionData) String() string {
+	return fmt.Sprintf("subscription %s %q endpoint=%s event_types=%v",
+		s.UID, s.Name, s.EndpointID, s.FilterConfig.EventTypes)
+}
+
+// String summarises the source on one line. Verifier secrets are not
+// included.
+func (s SourceData) String() string {
+	return fmt.Sprintf("source %s %q type=%s provider=%s %s", s.UID, s.Name, s.Type, s.Provider, s.URL)
+}
@@ -0,0 +1,121 @@
+package convoy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// maxFilterUpdates bounds how often a filter edit starts over after its
+// write was rejected with 409 Conflict.
+const maxFilterUpdates = 3
+
+// AddEventTypeToSubscription makes the subscription deliver eventType too.
+// It is a no-op when the subscription already delivers it, including when
+// its event types are empty or "*", which both match every type.
+func (we *webhookData) AddEventTypeToSubscription(projectID, subscriptionID, eventType string) (*Subscription, error) {
+	return we.editEventTypes(projectID, subscriptionID, func(types []string) ([]string, error) {
+		if len(types) == 0 || slices.Contains(types, eventType) || slices.Contains(types, "*") {
+			return nil, nil
+		}
+		return append(types, eventType), nil
+	})
+}
+
+// RemoveEventTypeFromSubscription stops the subscription delivering
+// eventType, keeping its other event types and filters. It fails for a
+// subscription matching every type, through "*" or an empty list, or
+// eventType alone, since Convoy treats an empty list as "*"; delete or pause
+// the endpoint instead.
+func (we *webhookData) RemoveEventTypeFromSubscription(projectID, subscriptionID, eventType string) (*Subscription, error) {
+	return we.editEventTypes(projectID, subscriptionID, func(types []string) ([]string, error) {
+		switch {
+		case len(types) == 0 || slices.Contains(types, "*"):
+			return nil, fmt.Errorf("subscription %s matches all event types, %q can't be removed", subscriptionID, eventType)
+		case !slices.Contains(types, eventType):
+			return nil, nil
+		case len(types) == 1:
+			return nil, fmt.Errorf("subscription %s would be left without event types", subscriptionID)
+		}
+		return slices.DeleteFunc(types, func(t string) bool { return t == eventType }), nil
+	})
+}
+
+// editEventTypes reads the subscription, applies edit to its event types and
+// writes the filter back; a nil result from edit leaves the subscription
+// untouched. Convoy has no conditional updates, so a change made between the
+// read and the write is overwritten; a write rejected with 409 Conflict, as
+// by a gateway enforcing versions, starts over. Filter values are decoded
+// with UseNumber so that large integers are written back exactly.
+func (we *webhookData) editEventTypes(projectID, subscriptionID string, edit func([]string) ([]string, error)) (*Subscription, error) {
+	var err error
+	for range maxFilterUpdates {
+		var subscription *Subscription
+		subscription, err = we.getSubscriptionExact(projectID, subscriptionID)
+		if err != nil {
+			return nil, err
+		}
+
+		filter := subscription.Data.FilterConfig
+		filter.EventTypes, err = edit(slices.Clone(filter.EventTypes))
+		if err != nil {
+			return nil, err
+		}
+		if filter.EventTypes == nil {
+			return subscription, nil
+		}
+
+		subscription, err = we.updateSubscriptionFilter(projectID, subscriptionID, filter)
+		if !errors.Is(err, ErrConflict) {
+			return subscription, err
+		}
+	}
+	return nil, err
+}
+
+// getSubscriptionExact is GetSubscription decoding numbers with UseNumber.
+func (we *webhookData) getSubscriptionExact(projectID, subscriptionID string) (*Subscription, error) {
+	var body []byte
+	err := we.do(request{
+		op:      "GetSubscription",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/subscriptions/", subscriptionID),
+		timeout: 2 * time.Second,
+	}, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription Subscription
+	exact := jsonDecoding{strict: we.strictDecoding, useNumber: true}
+	if err := exact.unmarshal(body, &subscription); err != nil {
+		return nil, fmt.Errorf("%w: decoding subscription: %w", ErrMalformedResponse, err)
+	}
+	return &subscription, nil
+}
+
+func (we *webhookData) updateSubscriptionFilter(projectID, subscriptionID string, filter FilterConfig) (*Subscription, error) {
+	body, err := json.Marshal(struct {
+		FilterConfig FilterConfig `json:"filter_config"`
+	}{filter})
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription Subscription
+	err = we.do(request{
+		op:      "UpdateSubscription",
+		method:  http.MethodPut,
+		path:    we.projectPath(projectID, "/subscriptions/", subscriptionID),
+		body:    body,
+		timeout: 2 * time.Second,
+	}, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
@@ -0,0 +1,79 @@
+package convoy_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	convoy "github.com/formflake/convoy-go"
+)
+
+func TestEditSubscriptionEventTypes(t *testing.T) {
+	srv, client := newFake(t)
+	endpoint, err := client.CreateEndpoint("", billingEndpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscription, err := client.CreateSubscription("", convoy.CreateSubscriptionParams{
+		Name:       "billing",
+		EndpointID: endpoint.Data.Uid,
+		FilterConfig: &convoy.FilterConfig{
+			EventTypes: []string{"invoice.paid"},
+			Filter:     convoy.Filter{Body: map[string]any{"account_id": json.Number("9007199254740993")}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := subscription.Data.UID
+
+	if _, err := client.AddEventTypeToSubscription("", id, "invoice.voided"); err != nil {
+		t.Fatal(err)
+	}
+	got := srv.Subscriptions()[0].FilterConfig
+	if !slices.Equal(got.EventTypes, []string{"invoice.paid", "invoice.voided"}) {
+		t.Errorf("event types after add = %v", got.EventTypes)
+	}
+	if n := got.Filter.Body["account_id"]; n != json.Number("9007199254740993") {
+		t.Errorf("account_id filter = %v, want 9007199254740993 unchanged", n)
+	}
+
+	if _, err := client.RemoveEventTypeFromSubscription("", id, "invoice.paid"); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.Subscriptions()[0].FilterConfig.EventTypes; !slices.Equal(got, []string{"invoice.voided"}) {
+		t.Errorf("event types after remove = %v", got)
+	}
+	if _, err := client.RemoveEventTypeFromSubscription("", id, "invoice.voided"); err == nil {
+		t.Error("removing the last event type succeeded")
+	}
+}
+
+func TestAddEventTypeKeepsEmptyList(t *testing.T) {
+	var puts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts++
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":true,"data":{"uid":"sub-1","filter_config":{"event_types":[],"filter":{}}}}`)
+	}))
+	defer srv.Close()
+	client, err := convoy.NewWebhook(srv.URL, "key", "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.AddEventTypeToSubscription("", "sub-1", "invoice.paid"); err != nil {
+		t.Fatal(err)
+	}
+	if puts != 0 {
+		t.Errorf("%d updates sent for a subscription that already matches every type", puts)
+	}
+	if _, err := client.RemoveEventTypeFromSubscription("", "sub-1", "invoice.paid"); err == nil {
+		t.Error("removing a type from a subscription matching every type succeeded")
+	}
+}
@@ -0,0 +1,187 @@
+package convoy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type Subscription struct {
+	Message string           `json:"message"`
+	Status  bool             `json:"status"`
+	Data    SubscriptionData `json:"data"`
+}
+
+type SubscriptionData struct {
+	UID          string                  `json:"uid"`
+	Name         string                  `json:"name"`
+	Type         string                  `json:"type"`
+	ProjectID    string                  `json:"project_id"`
+	SourceID     string                  `json:"source_id"`
+	EndpointID   string                  `json:"endpoint_id"`
+	FilterConfig FilterConfig            `json:"filter_config"`
+	RetryConfig  *SubscriptionRetryState `json:"retry_config"`
+	AlertConfig  *AlertConfig            `json:"alert_config"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+	DeletedAt    *time.Time              `json:"deleted_at"`
+}
+
+type SubscriptionList struct {
+	Message string                 `json:"message"`
+	Status  bool                   `json:"status"`
+	Data    Page[SubscriptionData] `json:"data"`
+}
+
+// SubscriptionQuery filters ListSubscriptions. Zero-valued fields are not
+// sent.
+type SubscriptionQuery struct {
+	EndpointID string
+
+	PerPage        int64
+	NextPageCursor string
+	PrevPageCursor string
+}
+
+func (q SubscriptionQuery) values() url.Values {
+	query := url.Values{}
+	if q.EndpointID != "" {
+		query.Set("endpointId", q.EndpointID)
+	}
+	setPageParams(query, q.PerPage, q.NextPageCursor, q.PrevPageCursor)
+	return query
+}
+
+type CreateSubscriptionParams struct {
+	Name         string        `json:"name"`
+	EndpointID   string        `json:"endpoint_id"`
+	SourceID     string        `json:"source_id,omitempty"`
+	FilterConfig *FilterConfig `json:"filter_config,omitempty"`
+	RetryConfig  *RetryConfig  `json:"retry_config,omitempty"`
+	AlertConfig  *AlertConfig  `json:"alert_config,omitempty"`
+}
+
+type FilterConfig struct {
+	// EventTypes lists the event types delivered; "*" matches all.
+	EventTypes []string `json:"event_types"`
+	Filter     Filter   `json:"filter"`
+}
+
+// Filter restricts the events a subscription delivers by matching event
+// headers and the event body separately. Build one with NewFilter.
+type Filter struct {
+	Headers map[string]any `json:"headers,omitempty"`
+	Body    map[string]any `json:"body,omitempty"`
+}
+
+// RetryConfig sets how Convoy retries failed deliveries of a subscription.
+type RetryConfig struct {
+	// Type is "linear" or "exponential".
+	Type string `json:"type"`
+	// Duration is the interval between retries as a Go duration string,
+	// e.g. "10s".
+	Duration   string `json:"duration"`
+	RetryCount uint64 `json:"retry_count"`
+}
+
+// SubscriptionRetryState is a subscription's retry configuration as Convoy
+// returns it.
+type SubscriptionRetryState struct {
+	Type string `json:"type"`
+	// Duration is the interval between retries in seconds.
+	Duration   uint64 `json:"duration"`
+	RetryCount uint64 `json:"retry_count"`
+}
+
+// AlertConfig makes Convoy notify the endpoint's support email and Slack
+// webhook once Count deliveries have failed within Threshold.
+type AlertConfig struct {
+	Count int `json:"count"`
+	// Threshold is a Go duration string, e.g. "1h".
+	Threshold string `json:"threshold"`
+}
+
+func (we *webhookData) CreateSubscription(projectID string, params CreateSubscriptionParams) (*Subscription, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription Subscription
+	err = we.do(request{
+		op:      "CreateSubscription",
+		method:  http.MethodPost,
+		path:    we.projectPath(projectID, "/subscriptions"),
+		body:    body,
+		timeout: 2 * time.Second,
+	}, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (we *webhookData) GetSubscription(projectID, subscriptionID string) (*Subscription, error) {
+	var subscription Subscription
+	err := we.do(request{
+		op:      "GetSubscription",
+		method:  http.MethodGet,
+		path:    we.projectPath(projectID, "/subscriptions/", subscriptionID),
+		timeout: 2 * time.Second,
+	}, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (we *webhookData) ListSubscriptions(projectID string, query SubscriptionQuery) (*SubscriptionList, error) {
+	return we.listSubscriptions(context.Background(), projectID, query)
+}
+
+func (we *webhookData) listSubscriptions(ctx context.Context, projectID string, query SubscriptionQuery) (*SubscriptionList, error) {
+	page, err := listPage[SubscriptionData](ctx, we, "ListSubscriptions", we.projectPath(projectID, "/subscriptions"), query.values())
+	if err != nil {
+		return nil, err
+	}
+	return (*SubscriptionList)(page), nil
+}
+
+// listAllSubscriptions follows the pagination of ListSubscriptions to the
+// end. When query.EndpointID is set, subscriptions of other endpoints are
+// dropped, since older servers ignore the filter.
+func (we *webhookData) listAllSubscriptions(ctx context.Context, projectID string, query SubscriptionQuery) ([]SubscriptionData, error) {
+	subscriptions, err := listAll[SubscriptionData](ctx, we, "ListSubscriptions", we.projectPath(projectID, "/subscriptions"), query.values())
+	if err != nil {
+		return nil, err
+	}
+	if query.EndpointID == "" {
+		return subscriptions, nil
+	}
+
+	var matching []SubscriptionData
+	for _, subscription := range subscriptions {
+		if subscription.EndpointID == query.EndpointID {
+			matching = append(matching, subscription)
+		}
+	}
+	return matching, nil
+}
+
+func (we *webhookData) DeleteSubscription(projectID, subscriptionID string) error {
+	return we.deleteSubscription(context.Background(), projectID, subscriptionID)
+}
+
+func (we *webhookData) deleteSubscription(ctx context.Context, projectID, subscriptionID string) error {
+	return we.do(request{
+		ctx:     ctx,
+		op:      "DeleteSubscription",
+		method:  http.MethodDelete,
+		path:    we.projectPath(projectID, "/subscriptions/", subscriptionID),
+		timeout: 2 * time.Second,
+	}, nil)
+}
@@ -0,0 +1,68 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Steps of SafeDeleteEndpoint reported in TeardownError.
+const (
+	TeardownStepListSubscriptions  = "list subscriptions"
+	TeardownStepDeleteSubscription = "delete subscription"
+	TeardownStepDeleteEndpoint     = "delete endpoint"
+)
+
+// TeardownError reports the step of SafeDeleteEndpoint that failed.
+// SubscriptionID is set for TeardownStepDeleteSubscription.
+type TeardownError struct {
+	Step           string
+	SubscriptionID string
+	Err            error
+}
+
+func (e *TeardownError) Error() string {
+	if e.SubscriptionID != "" {
+		return fmt.Sprintf("endpoint teardown failed at %s %s: %v", e.Step, e.SubscriptionID, e.Err)
+	}
+	return fmt.Sprintf("endpoint teardown failed at %s: %v", e.Step, e.Err)
+}
+
+func (e *TeardownError) Unwrap() error {
+	return e.Err
+}
+
+// SafeDeleteEndpoint deletes every subscription routing to the endpoint and
+// then the endpoint itself. Resources that are already gone are skipped, so
+// re-running it after a partial failure completes the teardown. Failures are
+// returned as *TeardownError.
+func (we *webhookData) SafeDeleteEndpoint(ctx context.Context, projectID, endpointID string) error {
+	query := SubscriptionQuery{EndpointID: endpointID}
+	for {
+		page, err := we.listSubscriptions(ctx, projectID, query)
+		if err != nil {
+			return &TeardownError{Step: TeardownStepListSubscriptions, Err: err}
+		}
+
+		for _, subscription := range page.Data.Content {
+			// Older servers ignore the endpoint filter.
+			if subscription.EndpointID != endpointID {
+				continue
+			}
+			err := we.deleteSubscription(ctx, projectID, subscription.UID)
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return &TeardownError{Step: TeardownStepDeleteSubscription, SubscriptionID: subscription.UID, Err: err}
+			}
+		}
+
+		if !page.Data.Pagination.HasNextPage {
+			break
+		}
+		query.NextPageCursor = page.Data.Pagination.NextPageCursor
+	}
+
+	if _, err := we.deleteEndpoint(ctx, projectID, endpointID); err != nil && !errors.Is(err, ErrNotFound) {
+		return &TeardownError{Step: TeardownStepDeleteEndpoint, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package convoy
+
+import (
+	"context"
+	"time"
+)
+
+// TestEventType is the event type of events sent to verify an endpoint.
+const TestEventType = "convoy.test"
+
+const testEventTimeout = 30 * time.Second
+
+// SendTestEvent sends a TestEventType event to the endpoint and waits until
+// Convoy has attempted to deliver it, returning the delivery with its status
+// and attempt count. If no attempt is made within 30 seconds the delivery
+// seen last, if any, is returned alongside the error.
+func (we *webhookData) SendTestEvent(projectID, endpointID string) (*EventDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), testEventTimeout)
+	defer cancel()
+
+	eventID, err := we.sendTestEvent(ctx, projectID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	return we.pollDelivery(ctx, projectID, endpointID, eventID, func(status DeliveryStatus) bool {
+		return status != DeliveryStatusScheduled && status != DeliveryStatusProcessing
+	})
+}
+
+// sendTestEvent creates a TestEventType event targeted at endpointID and
+// returns its ID.
+func (we *webhookData) sendTestEvent(ctx context.Context, projectID, endpointID string) (string, error) {
+	event, err := we.createEvent(ctx, projectID, &Webhook{
+		Data: WebhookData{
+			Data:       map[string]any{"test": true},
+			EventType:  TestEventType,
+			EndpointID: endpointID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return event.Data.UID, nil
+}
@@ -0,0 +1,174 @@
+package convoy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Times in responses are decoded leniently: Convoy versions have emitted RFC
+// 3339 with and without fractional seconds, with and without a zone, and
+// with a space instead of the "T". Times without a zone are taken to be UTC,
+// and every decoded time is converted to UTC.
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("convoy: unrecognised timestamp %q", s)
+}
+
+// normalize rewrites data so that t can decode it: timestamps time.Time can't
+// parse as RFC 3339 are reformatted, and bare arrays sent for a Page are
+// wrapped into {"content": [...]}.
+func normalize(data []byte, t reflect.Type) ([]byte, error) {
+	var tree any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	tree, err := normalizeValue(tree, t)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tree)
+}
+
+func normalizeValue(v any, t reflect.Type) (any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		s, ok := v.(string)
+		if !ok || s == "" {
+			return nil, nil
+		}
+		parsed, err := parseTimestamp(s)
+		if err != nil {
+			return nil, err
+		}
+		return parsed.Format(time.RFC3339Nano), nil
+	}
+
+	if array, ok := v.([]any); ok && isPage(t) {
+		v = map[string]any{"content": array}
+	}
+
+	var err error
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := v.(map[string]any)
+		if !ok {
+			return v, nil
+		}
+		for key, value := range object {
+			if field, ok := jsonField(t, key); ok {
+				if object[key], err = normalizeValue(value, field); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := v.([]any)
+		if !ok {
+			return v, nil
+		}
+		for i := range array {
+			if array[i], err = normalizeValue(array[i], t.Elem()); err != nil {
+				return nil, err
+			}
+		}
+	case reflect.Map:
+		object, ok := v.(map[string]any)
+		if !ok {
+			return v, nil
+		}
+		for key, value := range object {
+			if object[key], err = normalizeValue(value, t.Elem()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// jsonField returns the type of the field of struct type t that
+// encoding/json decodes the object key into.
+func jsonField(t reflect.Type, key string) (reflect.Type, bool) {
+	var folded reflect.Type
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if ft, ok := jsonField(embedded, key); ok {
+					return ft, true
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == key {
+			return field.Type, true
+		}
+		if folded == nil && strings.EqualFold(name, key) {
+			folded = field.Type
+		}
+	}
+	return folded, folded != nil
+}
+
+// toUTC converts every time.Time reachable from v through pointers, structs,
+// slices and arrays to UTC.
+func toUTC(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			toUTC(v.Elem())
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			if v.CanAddr() {
+				p := v.Addr().Interface().(*time.Time)
+				*p = p.UTC()
+			}
+			return
+		}
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				toUTC(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Pointer, reflect.Struct, reflect.Slice, reflect.Array:
+			for i := range v.Len() {
+				toUTC(v.Index(i))
+			}
+		}
+	}
+}
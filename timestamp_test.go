@@ -0,0 +1,50 @@
+package convoy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDecodeTimestampFormats(t *testing.T) {
+	want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, ts := range []string{
+		"2024-03-01T10:00:00Z",
+		"2024-03-01T11:00:00+01:00",
+		"2024-03-01T10:00:00",
+		"2024-03-01 10:00:00Z",
+		"2024-03-01 05:00:00-05:00",
+		"2024-03-01 10:00:00",
+	} {
+		t.Run(ts, func(t *testing.T) {
+			body := `{"status":true,"data":{"uid":"ep-1","created_at":"` + ts + `",
+				"updated_at":"2024-03-01T10:00:00.5Z","deleted_at":null,
+				"secrets":[{"uid":"sec-1","created_at":"` + ts + `","expires_at":null}]}}`
+			client := newStubClient(t, stubResponse(http.StatusOK, body))
+			resp, err := client.GetEndpoint("", "ep-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			ep := resp.Data
+			if !ep.CreatedAt.Equal(want) || ep.CreatedAt.Location() != time.UTC {
+				t.Errorf("created_at = %v, want %v", ep.CreatedAt, want)
+			}
+			if got := ep.Secrets[0].CreatedAt; !got.Equal(want) || got.Location() != time.UTC {
+				t.Errorf("secret created_at = %v, want %v", got, want)
+			}
+			if !ep.UpdatedAt.Equal(want.Add(500 * time.Millisecond)) {
+				t.Errorf("updated_at = %v, want it unchanged", ep.UpdatedAt)
+			}
+			if ep.DeletedAt != nil || ep.Secrets[0].ExpiresAt != nil {
+				t.Errorf("null times decoded as %v, %v; want nil", ep.DeletedAt, ep.Secrets[0].ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestDecodeTimestampRejectsGarbage(t *testing.T) {
+	client := newStubClient(t, stubResponse(http.StatusOK, `{"status":true,"data":{"created_at":"yesterday"}}`))
+	if _, err := client.GetEndpoint("", "ep-1"); err == nil {
+		t.Fatal("decoding an unparseable timestamp succeeded")
+	}
+}
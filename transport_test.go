@@ -0,0 +1,35 @@
+package convoy
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+)
+
+func TestWithMinTLSVersion(t *testing.T) {
+	client, err := NewWebhook("https://convoy.example.com", "key", "project", WithMinTLSVersion(tls.VersionTLS12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport, ok := client.WebhookInterface.(*webhookData).transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Fatalf("transport = %#v, want TLS 1.2 minimum", transport)
+	}
+
+	clone, err := client.Clone(WithMinTLSVersion(tls.VersionTLS13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cloned := clone.WebhookInterface.(*webhookData).transport.(*http.Transport)
+	if cloned.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("clone MinVersion = %x, want TLS 1.3", cloned.TLSClientConfig.MinVersion)
+	}
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("clone changed the original's MinVersion to %x", transport.TLSClientConfig.MinVersion)
+	}
+
+	if http.DefaultTransport.(*http.Transport).TLSClientConfig != nil &&
+		http.DefaultTransport.(*http.Transport).TLSClientConfig.MinVersion != 0 {
+		t.Error("WithMinTLSVersion changed http.DefaultTransport")
+	}
+}
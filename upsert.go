@@ -0,0 +1,90 @@
+package convoy
+
+import (
+	"context"
+	"fmt"
+)
+
+// EndpointMatch selects the field UpsertEndpoint identifies endpoints by.
+type EndpointMatch int
+
+const (
+	MatchByOwnerID EndpointMatch = iota
+	MatchByURL
+	MatchByName
+)
+
+func (m EndpointMatch) String() string {
+	switch m {
+	case MatchByOwnerID:
+		return "owner_id"
+	case MatchByURL:
+		return "url"
+	case MatchByName:
+		return "name"
+	}
+	return fmt.Sprintf("EndpointMatch(%d)", int(m))
+}
+
+// UpsertEndpoint updates the endpoint whose owner ID, URL or name, as chosen
+// by match, equals that of params, or creates one if there is none, and
+// returns its ID. If several endpoints match, nothing is changed and the
+// error wraps ErrConflict.
+//
+// The lookup and the write are separate requests, so concurrent calls for
+// the same key can still create two endpoints; set params.IdempotencyKey to
+// make retries of the create safe.
+func (we *webhookData) UpsertEndpoint(projectID string, params UpsertEndpointParams, match EndpointMatch) (string, error) {
+	var (
+		query EndpointQuery
+		value string
+	)
+	switch match {
+	case MatchByOwnerID:
+		query.OwnerID, value = params.OwnerID, params.OwnerID
+	case MatchByURL:
+		value = params.URL
+	case MatchByName:
+		query.Name, value = params.Name, params.Name
+	default:
+		return "", fmt.Errorf("convoy: unknown endpoint match %v", match)
+	}
+	if value == "" {
+		return "", fmt.Errorf("convoy: upsert by %s needs a %s", match, match)
+	}
+
+	endpoints, err := we.listAllEndpoints(context.Background(), projectID, query)
+	if err != nil {
+		return "", err
+	}
+	var ids []string
+	for _, endpoint := range endpoints {
+		var got string
+		switch match {
+		case MatchByOwnerID:
+			got = endpoint.OwnerID
+		case MatchByURL:
+			got = endpoint.URL
+		case MatchByName:
+			got = endpoint.Name
+		}
+		if got == value {
+			ids = append(ids, endpoint.UID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		created, err := we.CreateEndpoint(projectID, params)
+		if err != nil {
+			return "", err
+		}
+		return created.Data.Uid, nil
+	case 1:
+		if _, err := we.UpdateEndpoint(projectID, ids[0], params); err != nil {
+			return "", err
+		}
+		return ids[0], nil
+	}
+	return "", fmt.Errorf("%w: %d endpoints have %s %q: %v", ErrConflict, len(ids), match, value, ids)
+}
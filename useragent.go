@@ -0,0 +1,29 @@
+package convoy
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+const modulePath = "github.com/formflake/convoy-go"
+
+// baseUserAgent identifies the SDK and, when the build records it, its
+// module version.
+var baseUserAgent = sync.OnceValue(func() string {
+	version := "devel"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				version = dep.Version
+			}
+		}
+	}
+	return "convoy-go/" + version
+})
+
+func (we *webhookData) userAgent() string {
+	if we.userAgentSuffix == "" {
+		return baseUserAgent()
+	}
+	return baseUserAgent() + " (" + we.userAgentSuffix + ")"
+}
@@ -0,0 +1,105 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedByServer is returned when the Convoy server is too old for a
+// feature.
+var ErrUnsupportedByServer = errors.New("convoy: unsupported by server version")
+
+// MinServerVersion is the oldest Convoy release the client supports. Every
+// method needs at least this version; the few features beyond it are
+// detected at runtime and reported with ErrUnsupportedByServer: full-text
+// SearchEvents queries, which need a server with search configured, and
+// WebhookData.DeliverAt, which no release supports yet. Against an older
+// server, methods may fail with ErrNotFound or decode incomplete data.
+const MinServerVersion = "v24.1.0"
+
+// serverVersion caches the version reported by the server. It is shared by
+// clients derived through ForProject and Clone.
+type serverVersion = fetchCache[struct{}, string]
+
+// GetServerVersion returns the version of the Convoy server, such as
+// "v24.1.4". The first successful answer is cached for the client's life;
+// concurrent first calls share one request.
+func (we *webhookData) GetServerVersion(ctx context.Context) (string, error) {
+	return we.serverVersion.get(ctx, struct{}{}, func() (string, error) {
+		return we.fetchServerVersion(ctx)
+	})
+}
+
+func (we *webhookData) fetchServerVersion(ctx context.Context) (string, error) {
+	var health struct {
+		Data string `json:"data"`
+	}
+	err := we.do(request{
+		ctx:     ctx,
+		op:      "GetServerVersion",
+		method:  http.MethodGet,
+		path:    "/",
+		timeout: 2 * time.Second,
+	}, &health)
+	if err != nil {
+		return "", err
+	}
+	if health.Data == "" {
+		return "", fmt.Errorf("%w: server did not report a version", ErrMalformedResponse)
+	}
+	return health.Data, nil
+}
+
+// RequireServerVersion returns an error wrapping ErrUnsupportedByServer if
+// the server is older than minVersion, e.g. MinServerVersion. Use it to gate
+// features that only newer servers provide.
+func (we *webhookData) RequireServerVersion(ctx context.Context, minVersion string) error {
+	version, err := we.GetServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if compareVersions(version, minVersion) < 0 {
+		return fmt.Errorf("%w: server is %s, need %s or later", ErrUnsupportedByServer, version, minVersion)
+	}
+	return nil
+}
+
+// compareVersions compares dotted versions numerically, ignoring a leading
+// "v" and any pre-release or build suffix.
+func compareVersions(a, b string) int {
+	as, bs := versionParts(a), versionParts(b)
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, field := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(field)
+		parts = append(parts, n)
+	}
+	return parts
+}
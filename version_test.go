@@ -0,0 +1,50 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetServerVersionSharesFetch(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		<-release
+		return stubResponse(http.StatusOK, `{"status":true,"data":"v24.1.4"}`)(r)
+	})
+
+	// A caller that gives up isn't held by the fetch in flight.
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if version, err := client.GetServerVersion(context.Background()); err != nil || version != "v24.1.4" {
+				t.Errorf("GetServerVersion = %q, %v", version, err)
+			}
+		}()
+	}
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetServerVersion(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("cancelled caller got %v, want context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if n := requests.Load(); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+	if err := client.RequireServerVersion(context.Background(), MinServerVersion); err != nil {
+		t.Error(err)
+	}
+}
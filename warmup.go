@@ -0,0 +1,43 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Ping checks that the Convoy server answers. It does not authenticate.
+func (we *webhookData) Ping(ctx context.Context) error {
+	return we.do(request{
+		ctx:     ctx,
+		op:      "Ping",
+		method:  http.MethodGet,
+		path:    "/",
+		timeout: 2 * time.Second,
+	}, nil)
+}
+
+// Warmup verifies the client can serve traffic, for use as a readiness
+// check: it pings the server, then lists one endpoint of the default project
+// to validate the credentials and open a pooled connection. The error wraps
+// ErrUnreachable, ErrUnauthorized or ErrWrongProject accordingly: a 401 means
+// the key is rejected, while a 403 or 404 means it is valid but the default
+// project doesn't exist or belongs to another key.
+func (we *webhookData) Warmup(ctx context.Context) error {
+	if err := we.Ping(ctx); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrUnreachable, we.url, err)
+	}
+
+	_, err := we.listEndpoints(ctx, "", EndpointQuery{PerPage: 1})
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusForbidden):
+		// Formatted with %v so that a 403 doesn't also match ErrUnauthorized.
+		return fmt.Errorf("%w: %s: %v", ErrWrongProject, we.defaultProject, err)
+	}
+	return err
+}
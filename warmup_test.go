@@ -0,0 +1,42 @@
+package convoy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestWarmupErrors(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		ping, list int
+		want, not  error
+	}{
+		{"unreachable", http.StatusBadGateway, http.StatusOK, ErrUnreachable, nil},
+		{"rejected key", http.StatusOK, http.StatusUnauthorized, ErrUnauthorized, ErrWrongProject},
+		{"forbidden project", http.StatusOK, http.StatusForbidden, ErrWrongProject, ErrUnauthorized},
+		{"missing project", http.StatusOK, http.StatusNotFound, ErrWrongProject, ErrNotFound},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			client := newStubClient(t, func(r *http.Request) (*http.Response, error) {
+				if r.URL.Path == "/" {
+					return stubResponse(c.ping, `{}`)(r)
+				}
+				return stubResponse(c.list, `{"status":false,"message":"no"}`)(r)
+			})
+			err := client.Warmup(context.Background())
+			if !errors.Is(err, c.want) {
+				t.Errorf("err = %v, want %v", err, c.want)
+			}
+			if c.not != nil && errors.Is(err, c.not) {
+				t.Errorf("err = %v, should not match %v", err, c.not)
+			}
+		})
+	}
+
+	client := newStubClient(t, stubResponse(http.StatusOK, `{"status":true,"data":{"content":[]}}`))
+	if err := client.Warmup(context.Background()); err != nil {
+		t.Errorf("healthy warmup: %v", err)
+	}
+}